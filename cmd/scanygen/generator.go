@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+type generator struct {
+	tagKey    string
+	separator string
+}
+
+// fieldStep is a single field selector on the way from the struct to a column field.
+type fieldStep struct {
+	name string
+	// newType is set if the field is a pointer to a struct that must be initialized before use.
+	newType types.Type
+}
+
+type column struct {
+	name string
+	path []fieldStep
+}
+
+type toTraverse struct {
+	st           *types.Struct
+	pathPrefix   []fieldStep
+	columnPrefix string
+}
+
+func (g *generator) generate(dir, outputName string, typeNames []string) ([]byte, error) {
+	pkg, err := g.loadPackage(dir, outputName)
+	if err != nil {
+		return nil, err
+	}
+	imports := newImportSet(pkg)
+
+	var body bytes.Buffer
+	for _, typeName := range typeNames {
+		obj := pkg.Scope().Lookup(typeName)
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.Name())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a named type", typeName)
+		}
+		if named.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("%s: generic types are not supported", typeName)
+		}
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct type", typeName)
+		}
+		columns, err := g.buildColumns(pkg, st)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typeName, err)
+		}
+		g.writeColumnPointers(&body, typeName, columns, imports.qualifier)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by scanygen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg.Name())
+	out.WriteString("import (\n")
+	paths := make([]string, 0, len(imports.names))
+	for path := range imports.names {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if name := imports.names[path]; name != imports.pkgNames[path] {
+			fmt.Fprintf(&out, "\t%s %s\n", name, strconv.Quote(path))
+			continue
+		}
+		fmt.Fprintf(&out, "\t%s\n", strconv.Quote(path))
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// importSet tracks the packages referenced by the generated code.
+// A package whose name clashes with another import or with an identifier of the generated package
+// gets an alias with a numeric suffix.
+type importSet struct {
+	pkg *types.Package
+	// names maps an import path to the name it's referenced by in the generated code.
+	names map[string]string
+	// pkgNames maps an import path to the name declared by the package.
+	pkgNames map[string]string
+	used     map[string]bool
+}
+
+func newImportSet(pkg *types.Package) *importSet {
+	const dbscanPath = "github.com/georgysavva/scany/v2/dbscan"
+	return &importSet{
+		pkg:      pkg,
+		names:    map[string]string{dbscanPath: "dbscan"},
+		pkgNames: map[string]string{dbscanPath: "dbscan"},
+		used:     map[string]bool{"dbscan": true},
+	}
+}
+
+func (is *importSet) qualifier(other *types.Package) string {
+	if other == is.pkg {
+		return ""
+	}
+	if name, ok := is.names[other.Path()]; ok {
+		return name
+	}
+	name := other.Name()
+	for i := 2; is.used[name] || is.pkg.Scope().Lookup(name) != nil; i++ {
+		name = other.Name() + strconv.Itoa(i)
+	}
+	is.names[other.Path()] = name
+	is.pkgNames[other.Path()] = other.Name()
+	is.used[name] = true
+	return name
+}
+
+func (g *generator) loadPackage(dir, outputName string) (*types.Package, error) {
+	buildPkg, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("loading package: %w", err)
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == outputName {
+			// Skip the previously generated file, it might be outdated.
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", name, err)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(buildPkg.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, fmt.Errorf("type checking package: %w", err)
+	}
+	return pkg, nil
+}
+
+// buildColumns mirrors how dbscan maps struct fields to columns via reflection.
+func (g *generator) buildColumns(pkg *types.Package, structType *types.Struct) ([]column, error) {
+	var result []column
+	seen := make(map[string]struct{})
+	queue := []*toTraverse{{st: structType}}
+	for len(queue) > 0 {
+		traversal := queue[0]
+		queue = queue[1:]
+		st := traversal.st
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+
+			if !field.Exported() && !field.Anonymous() {
+				// Field is unexported, skip it.
+				continue
+			}
+
+			dbTag, dbTagPresent := reflect.StructTag(st.Tag(i)).Lookup(g.tagKey)
+			if dbTagPresent {
+				dbTag = strings.Split(dbTag, ",")[0]
+			}
+			if dbTag == "-" {
+				// Field is ignored, skip it.
+				continue
+			}
+
+			if !field.Exported() && field.Pkg() != pkg {
+				return nil, fmt.Errorf("field %s of an unexported type from package %s can't be accessed",
+					field.Name(), field.Pkg().Path())
+			}
+
+			childType := field.Type()
+			step := fieldStep{name: field.Name()}
+			if ptr, ok := childType.Underlying().(*types.Pointer); ok {
+				childType = ptr.Elem()
+				if _, ok := childType.Underlying().(*types.Struct); ok {
+					step.newType = childType
+				}
+			}
+			path := make([]fieldStep, 0, len(traversal.pathPrefix)+1)
+			path = append(path, traversal.pathPrefix...)
+			path = append(path, step)
+
+			columnPart := dbTag
+			if !dbTagPresent {
+				columnPart = dbscan.SnakeCaseMapper(field.Name())
+			}
+			if !field.Anonymous() {
+				name := g.buildColumn(traversal.columnPrefix, columnPart)
+				if _, exists := seen[name]; !exists {
+					seen[name] = struct{}{}
+					result = append(result, column{name: name, path: path})
+				}
+			}
+
+			if childStruct, ok := childType.Underlying().(*types.Struct); ok {
+				if field.Anonymous() {
+					// If "db" tag is present for embedded struct
+					// use it with "." to prefix all column from the embedded struct.
+					// the default behavior is to propagate columns as is.
+					columnPart = dbTag
+				}
+				queue = append(queue, &toTraverse{
+					st:           childStruct,
+					pathPrefix:   path,
+					columnPrefix: g.buildColumn(traversal.columnPrefix, columnPart),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+func (g *generator) buildColumn(parts ...string) string {
+	var notEmptyParts []string
+	for _, p := range parts {
+		if p != "" {
+			notEmptyParts = append(notEmptyParts, p)
+		}
+	}
+	return strings.Join(notEmptyParts, g.separator)
+}
+
+func (g *generator) writeColumnPointers(w *bytes.Buffer, typeName string, columns []column, qualifier types.Qualifier) {
+	fmt.Fprintf(w, "\nvar _ dbscan.ColumnPointerer = (*%s)(nil)\n\n", typeName)
+	fmt.Fprintf(w, "// ColumnMapping implements the dbscan.ColumnPointerer interface.\n")
+	fmt.Fprintf(w, "func (*%s) ColumnMapping() (owner interface{}, tagKey, separator string) {\n", typeName)
+	fmt.Fprintf(w, "return (*%s)(nil), %s, %s\n}\n\n", typeName, strconv.Quote(g.tagKey), strconv.Quote(g.separator))
+	fmt.Fprintf(w, "// ColumnPointers implements the dbscan.ColumnPointerer interface.\n")
+	fmt.Fprintf(w, "func (s *%s) ColumnPointers(columns []string, pointers []interface{}) {\n", typeName)
+	fmt.Fprintf(w, "for i, column := range columns {\n")
+	fmt.Fprintf(w, "switch column {\n")
+	for _, c := range columns {
+		fmt.Fprintf(w, "case %s:\n", strconv.Quote(c.name))
+		selector := "s"
+		for _, step := range c.path {
+			selector += "." + step.name
+			if step.newType != nil {
+				fmt.Fprintf(w, "if %s == nil {\n%s = new(%s)\n}\n",
+					selector, selector, types.TypeString(step.newType, qualifier))
+			}
+		}
+		fmt.Fprintf(w, "pointers[i] = &%s\n", selector)
+	}
+	fmt.Fprintf(w, "}\n}\n}\n")
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name      string
+		dir       string
+		typeNames []string
+	}{
+		{
+			name:      "models",
+			dir:       "models",
+			typeNames: []string{"User", "Post"},
+		},
+		{
+			name:      "import name clash",
+			dir:       "clash",
+			typeNames: []string{"Place"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			dir := filepath.Join("testdata", tc.dir)
+			goldenPath := filepath.Join(dir, tc.dir+"_scany.go")
+			g := &generator{tagKey: "db", separator: "."}
+
+			got, err := g.generate(dir, filepath.Base(goldenPath), tc.typeNames)
+			require.NoError(t, err)
+
+			if *update {
+				require.NoError(t, os.WriteFile(goldenPath, got, 0o644)) //nolint: gosec
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(got))
+
+			// Make sure the generated code compiles along with the package.
+			_, err = g.loadPackage(dir, "")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestGenerate_invalidType_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		typeName    string
+		expectedErr string
+	}{
+		{
+			name:        "not found",
+			typeName:    "Unknown",
+			expectedErr: "type Unknown not found in package models",
+		},
+		{
+			name:        "not struct",
+			typeName:    "NotStruct",
+			expectedErr: "NotStruct is not a struct type",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := &generator{tagKey: "db", separator: "."}
+			_, err := g.generate(filepath.Join("testdata", "models"), "models_scany.go", []string{tc.typeName})
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
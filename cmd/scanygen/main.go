@@ -0,0 +1,75 @@
+// Command scanygen generates reflection-free scanning code for structs.
+/*
+For each struct type it generates ColumnPointers and ColumnMapping methods that implement the dbscan.ColumnPointerer interface.
+RowScanner detects that a destination implements this interface
+and uses the generated code to get pointers to the struct fields instead of reflection.
+The generated code follows exactly the same mapping rules as dbscan does:
+struct tags, ignored fields, embedded and nested structs, including nested structs by a pointer.
+
+Usage:
+
+	scanygen -type User,Post [-tag db] [-separator .] [-output user_scany.go] [dir]
+
+It's meant to be used with go generate:
+
+	//go:generate scanygen -type User
+
+	type User struct {
+		ID   string `db:"user_id"`
+		Name string
+	}
+
+Field names are translated to column names with dbscan.SnakeCaseMapper.
+The generated code is only used by API instances that use the default field name mapper
+and the same struct tag key and column separator as passed to scanygen, others fall back to reflection.
+Types that embed a struct with the generated code need their own generated code,
+otherwise they are scanned via reflection as well.
+Packages whose names clash with other imports are imported with an alias.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	tagKey := flag.String("tag", "db", "struct tag key")
+	separator := flag.String("separator", ".", "column separator for nested structs")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_scany.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: scanygen -type T [flags] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	types := strings.Split(*typeNames, ",")
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = filepath.Join(dir, dbscan.SnakeCaseMapper(types[0])+"_scany.go")
+	}
+
+	g := &generator{tagKey: *tagKey, separator: *separator}
+	src, err := g.generate(dir, filepath.Base(outputPath), types)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scanygen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil { //nolint: gosec
+		fmt.Fprintf(os.Stderr, "scanygen: writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,11 @@
+package clash
+
+import (
+	firstgeo "github.com/georgysavva/scany/v2/cmd/scanygen/testdata/clash/first/geo"
+	"github.com/georgysavva/scany/v2/cmd/scanygen/testdata/clash/second/geo"
+)
+
+type Place struct {
+	Point *firstgeo.Point
+	Area  *geo.Area
+}
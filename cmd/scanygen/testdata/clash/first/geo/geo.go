@@ -0,0 +1,6 @@
+package geo
+
+type Point struct {
+	Lat float64
+	Lng float64
+}
@@ -0,0 +1,5 @@
+package geo
+
+type Area struct {
+	Name string
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type User struct {
+	ID        string `db:"user_id"`
+	FullName  string
+	Email     sql.NullString
+	CreatedAt time.Time
+	Ignored   string `db:"-"`
+	private   string
+}
+
+type Post struct {
+	*User
+	ID      string
+	Text    string `db:"body,omitempty"`
+	Author  *User  `db:"author"`
+	Comment Comment
+	meta
+}
+
+type Comment struct {
+	Body string
+}
+
+type meta struct {
+	Version int
+}
+
+type NotStruct string
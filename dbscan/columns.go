@@ -0,0 +1,196 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScanColumns is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanColumns for details.
+func ScanColumns(dst interface{}, rows Rows) error {
+	return DefaultAPI.ScanColumns(dst, rows)
+}
+
+// ScanColumns iterates all rows to the end and scans data column-wise:
+// the value of each column is appended to its own slice. After iterating it closes the rows,
+// and propagates any errors that could pop up.
+// It expects that destination should be a pointer to a struct with slice fields,
+// or a pointer to a map with a string key and slice values, for example:
+//
+//	type UserColumns struct {
+//	    IDs   []int64  `db:"id"`
+//	    Names []string `db:"name"`
+//	}
+//
+//	var byStruct UserColumns
+//	var byMap map[string][]interface{}
+//
+// Both byStruct and byMap are valid destinations for ScanColumns function.
+// Columns are matched to struct fields with the same rules that are used for scanning rows into structs,
+// except that fields must be slices. Slice elements are passed to the underlying Rows.Scan(),
+// so they can be of any type the database library can scan into, e.g. *string for NULLs.
+//
+// Before starting, ScanColumns resets the destination slices,
+// so if they aren't empty it will overwrite all existing elements.
+func (api *API) ScanColumns(dst interface{}, rows Rows) error {
+	defer rows.Close() //nolint: errcheck
+	dstValue, err := parseDestination(dst)
+	if err != nil {
+		return fmt.Errorf("parsing destination: %w", err)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	if err := ensureDistinctColumns(columns); err != nil {
+		return fmt.Errorf("duplicate columns: %w", err)
+	}
+	cs, err := api.newColumnsScanner(dstValue, columns)
+	if err != nil {
+		return fmt.Errorf("starting: %w", err)
+	}
+	for rows.Next() {
+		if err := cs.scan(rows); err != nil {
+			return fmt.Errorf("scanning: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("scany: rows final error: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("scany: close rows after processing: %w", err)
+	}
+	cs.finish()
+	return nil
+}
+
+// columnsScanner appends values of each column to the corresponding slice.
+type columnsScanner struct {
+	// slices[i] is invalid if the column doesn't have a corresponding slice.
+	slices  []reflect.Value
+	buffers []reflect.Value
+	scans   []interface{}
+	// finish stores the slices into the destination after all rows are scanned.
+	finish func()
+}
+
+func (api *API) newColumnsScanner(dstValue reflect.Value, columns []string) (*columnsScanner, error) {
+	cs := &columnsScanner{
+		slices:  make([]reflect.Value, len(columns)),
+		buffers: make([]reflect.Value, len(columns)),
+		scans:   make([]interface{}, len(columns)),
+		finish:  func() {},
+	}
+	var err error
+	switch dstValue.Kind() {
+	case reflect.Struct:
+		err = api.startColumnsStruct(cs, dstValue, columns)
+	case reflect.Map:
+		err = startColumnsMap(cs, dstValue, columns)
+	default:
+		err = fmt.Errorf("scany: destination must be a struct or a map, got: %v", dstValue.Type())
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, slice := range cs.slices {
+		if !slice.IsValid() {
+			var tmp noOpScanType
+			cs.scans[i] = &tmp
+			continue
+		}
+		// Make sure slice is empty.
+		slice.Set(slice.Slice(0, 0))
+		valuePtr := reflect.New(slice.Type().Elem())
+		cs.scans[i] = valuePtr.Interface()
+		cs.buffers[i] = valuePtr.Elem()
+	}
+	return cs, nil
+}
+
+func (api *API) startColumnsStruct(cs *columnsScanner, structValue reflect.Value, columns []string) error {
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structValue.Type())
+	for i, column := range columns {
+		fieldIndex, ok := columnToFieldIndex[column]
+		if !ok {
+			if api.allowUnknownColumns {
+				continue
+			}
+			return fmt.Errorf(
+				"scany: column: '%s': no corresponding field found, or it's unexported in %v",
+				column, structValue.Type(),
+			)
+		}
+		fieldVal := structValue
+		for j, x := range fieldIndex {
+			if j > 0 && fieldVal.Kind() == reflect.Ptr {
+				if fieldVal.IsNil() {
+					fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+				}
+				fieldVal = fieldVal.Elem()
+			}
+			fieldVal = fieldVal.Field(x)
+		}
+		if fieldVal.Kind() != reflect.Slice {
+			return fmt.Errorf(
+				"scany: column: '%s': corresponding field must be a slice, got: %v",
+				column, fieldVal.Type(),
+			)
+		}
+		cs.slices[i] = fieldVal
+	}
+	return nil
+}
+
+func startColumnsMap(cs *columnsScanner, mapValue reflect.Value, columns []string) error {
+	mapType := mapValue.Type()
+	if mapType.Key().Kind() != reflect.String {
+		return fmt.Errorf(
+			"scany: invalid type %v: map must have string key, got: %v",
+			mapType, mapType.Key(),
+		)
+	}
+	if mapType.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf(
+			"scany: invalid type %v: map must have slice values, got: %v",
+			mapType, mapType.Elem(),
+		)
+	}
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMapWithSize(mapType, len(columns)))
+	}
+	keys := make([]reflect.Value, len(columns))
+	for i, column := range columns {
+		keys[i] = reflect.ValueOf(column).Convert(mapType.Key())
+		// Map elements aren't addressable, so slices are accumulated separately
+		// and stored into the map at the end.
+		slice := reflect.New(mapType.Elem()).Elem()
+		if existing := mapValue.MapIndex(keys[i]); existing.IsValid() {
+			slice.Set(existing)
+		}
+		cs.slices[i] = slice
+	}
+	cs.finish = func() {
+		for i, key := range keys {
+			mapValue.SetMapIndex(key, cs.slices[i])
+		}
+	}
+	return nil
+}
+
+func (cs *columnsScanner) scan(rows Rows) error {
+	for _, buffer := range cs.buffers {
+		if buffer.IsValid() {
+			buffer.Set(reflect.Zero(buffer.Type()))
+		}
+	}
+	if err := rows.Scan(cs.scans...); err != nil {
+		return fmt.Errorf("scany: scan row into columns: %w", err)
+	}
+	for i, slice := range cs.slices {
+		if slice.IsValid() {
+			slice.Set(reflect.Append(slice, cs.buffers[i]))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package dbscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+func newColumnsRows() *dbscantest.FakeRows {
+	return dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"id", "name", "nested.score"},
+		Rows: [][]interface{}{
+			{int64(1), makeStrPtr("foo"), 1.5},
+			{int64(2), nil, 2.5},
+		},
+	})
+}
+
+func TestScanColumns(t *testing.T) {
+	t.Parallel()
+	type Nested struct {
+		Scores []float64 `db:"score"`
+	}
+	type Columns struct {
+		IDs    []int64   `db:"id"`
+		Names  []*string `db:"name"`
+		Nested *Nested
+	}
+	cases := []struct {
+		name     string
+		dst      interface{}
+		expected interface{}
+	}{
+		{
+			name: "struct",
+			dst:  &Columns{IDs: []int64{100, 200, 300}},
+			expected: Columns{
+				IDs:    []int64{1, 2},
+				Names:  []*string{makeStrPtr("foo"), nil},
+				Nested: &Nested{Scores: []float64{1.5, 2.5}},
+			},
+		},
+		{
+			name: "map",
+			dst:  &map[string][]interface{}{"id": {100}},
+			expected: map[string][]interface{}{
+				"id":           {int64(1), int64(2)},
+				"name":         {makeStrPtr("foo"), nil},
+				"nested.score": {1.5, 2.5},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := newColumnsRows()
+
+			err := testAPI.ScanColumns(tc.dst, rows)
+			require.NoError(t, err)
+
+			assertDestinationEqual(t, tc.expected, tc.dst)
+			assert.True(t, rows.Closed())
+		})
+	}
+}
+
+func TestScanColumns_allowUnknownColumns(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithAllowUnknownColumns(true))
+	require.NoError(t, err)
+	dst := &struct {
+		IDs []int64 `db:"id"`
+	}{}
+
+	err = api.ScanColumns(dst, newColumnsRows())
+	require.NoError(t, err)
+
+	assert.Equal(t, []int64{1, 2}, dst.IDs)
+}
+
+func TestScanColumns_invalidDestination_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		dst         interface{}
+		expectedErr string
+	}{
+		{
+			name: "unknown column",
+			dst: &struct {
+				IDs []int64 `db:"id"`
+			}{},
+			expectedErr: "starting: scany: column: 'name': no corresponding field found, or it's unexported in " +
+				"struct { IDs []int64 \"db:\\\"id\\\"\" }",
+		},
+		{
+			name: "non slice field",
+			dst: &struct {
+				ID int64
+			}{},
+			expectedErr: "starting: scany: column: 'id': corresponding field must be a slice, got: int64",
+		},
+		{
+			name:        "map with non slice values",
+			dst:         &map[string]interface{}{},
+			expectedErr: "starting: scany: invalid type map[string]interface {}: map must have slice values, got: interface {}",
+		},
+		{
+			name:        "slice",
+			dst:         &[]int64{},
+			expectedErr: "starting: scany: destination must be a struct or a map, got: []int64",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := testAPI.ScanColumns(tc.dst, newColumnsRows())
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
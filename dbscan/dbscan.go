@@ -63,8 +63,22 @@ type API struct {
 	scannableTypesOption  []interface{}
 	scannableTypesReflect []reflect.Type
 	allowUnknownColumns   bool
+	clearMapDestination   bool
+	mapBytesToString      bool
+	columnTypedMaps       bool
+	nestedMaps            bool
 	// columnToIndexFieldMapCache stores a map of reflect.Type -> map[string][]int
 	columnToIndexFieldMapCache sync.Map
+	// columnFieldsCache stores a map of reflect.Type -> []columnField
+	columnFieldsCache sync.Map
+	// dynamicTypesCache stores a map of columns signature -> reflect.Type,
+	// dynamicTypesCount is the number of its entries.
+	dynamicTypesCache sync.Map
+	dynamicTypesCount int32
+	// structPlansCache stores a map of struct type and columns -> []columnPlan,
+	// structPlansCount is the number of its entries.
+	structPlansCache sync.Map
+	structPlansCount int32
 }
 
 // APIOption is a function type that changes API configuration.
@@ -152,6 +166,50 @@ func WithAllowUnknownColumns(allowUnknownColumns bool) APIOption {
 	}
 }
 
+// WithClearMapDestination makes the scanner delete all existing keys from a non-nil map destination
+// before scanning a row into it. This allows reusing the same map for every row when iterating rows manually,
+// see RowScanner, without leftovers from previous usages.
+// By default, a non-nil map destination keeps its existing keys and only the keys for the row columns are overwritten.
+func WithClearMapDestination(clearMapDestination bool) APIOption {
+	return func(api *API) {
+		api.clearMapDestination = clearMapDestination
+	}
+}
+
+// WithMapBytesToString makes the scanner convert []byte values to string
+// when scanning into a map with interface{} values, e.g. map[string]interface{}.
+// Some database/sql drivers return text columns as []byte, this option makes such map values consistent.
+// Note that it converts binary columns as well.
+func WithMapBytesToString(mapBytesToString bool) APIOption {
+	return func(api *API) {
+		api.mapBytesToString = mapBytesToString
+	}
+}
+
+// WithColumnTypedMaps makes the scanner choose a Go type for every column
+// when scanning into a map with interface{} values, e.g. map[string]interface{}.
+// The types are taken from rows that implement the ColumnScanTyper interface,
+// and NULL values end up as nil map values.
+// This makes map values the same regardless of the types the database driver picks by default.
+// Rows that don't implement ColumnScanTyper are scanned as usual.
+func WithColumnTypedMaps(columnTypedMaps bool) APIOption {
+	return func(api *API) {
+		api.columnTypedMaps = columnTypedMaps
+	}
+}
+
+// WithNestedMaps makes the scanner build nested maps from column names joined with the column separator
+// when scanning into a map with interface{} values, e.g. map[string]interface{}.
+// For example, columns "user.id" and "user.email" end up as {"user": {"id": ..., "email": ...}},
+// the same way as they are mapped to nested structs. Nested maps have the type of the destination map
+// and are allocated anew for every row.
+// Rows can't contain both a column and columns nested under it, e.g. "user" and "user.id".
+func WithNestedMaps(nestedMaps bool) APIOption {
+	return func(api *API) {
+		api.nestedMaps = nestedMaps
+	}
+}
+
 // StructTagKey returns the struct tag key used by the API.
 func (api *API) StructTagKey() string {
 	return api.structTagKey
@@ -201,21 +259,74 @@ func (api *API) ScanOne(dst interface{}, rows Rows) error {
 	return api.processRows(dst, rows, false /* multipleRows. */, true /* closeRows. */)
 }
 
+// ScanAllSetsStrict is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSetsStrict for details.
+func ScanAllSetsStrict(dsts []interface{}, rows Rows) error {
+	return DefaultAPI.ScanAllSetsStrict(dsts, rows)
+}
+
 // ScanAllSets iterates all rows to the end and scans data into each destination.
 // Multiple destinations is supported by multiple result sets.
+// By default, each result set is scanned the same way as ScanAll does it,
+// wrap a destination with One to scan the corresponding result set the same way as ScanOne does it.
+// A nil destination skips the corresponding result set.
+// If there are fewer result sets than destinations, remaining destinations are left untouched.
+// Use ScanAllSetsStrict to treat that as an error.
 func (api *API) ScanAllSets(dsts []interface{}, rows Rows) error {
+	return api.processResultSets(dsts, rows, false /* strict */)
+}
+
+// ScanAllSetsStrict works like ScanAllSets,
+// but it returns an error if the number of result sets doesn't match the number of destinations.
+func (api *API) ScanAllSetsStrict(dsts []interface{}, rows Rows) error {
+	return api.processResultSets(dsts, rows, true /* strict */)
+}
+
+// One wraps a destination passed to ScanAllSets or ScanAllSetsStrict,
+// so the corresponding result set is scanned the same way as ScanOne does it:
+// it must contain exactly one row.
+func One(dst interface{}) interface{} {
+	return oneDestination{dst: dst}
+}
+
+type oneDestination struct {
+	dst interface{}
+}
+
+func (api *API) processResultSets(dsts []interface{}, rows Rows, strict bool) error {
 	defer rows.Close() //nolint: errcheck
 	for i, dst := range dsts {
-		if err := api.processRows(dst, rows, true, false /* closeRows */); err != nil {
-			return fmt.Errorf("error processing destination %d: %w", i, err)
+		if i > 0 && !rows.NextResultSet() {
+			if !strict {
+				break
+			}
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("scany: rows final error: %w", err)
+			}
+			return fmt.Errorf("scany: expected %d result sets, got: %d", len(dsts), i)
 		}
-		if !rows.NextResultSet() {
-			break
+		if err := api.processResultSet(dst, rows); err != nil {
+			return fmt.Errorf("error processing destination %d: %w", i, err)
 		}
 	}
+	if strict && len(dsts) > 0 && rows.NextResultSet() {
+		return fmt.Errorf("scany: expected %d result sets, got more", len(dsts))
+	}
 	return nil
 }
 
+func (api *API) processResultSet(dst interface{}, rows Rows) error {
+	switch d := dst.(type) {
+	case nil:
+		// Skip the result set, moving to the next one discards its rows.
+		return nil
+	case oneDestination:
+		return api.processRows(d.dst, rows, false /* multipleRows. */, false /* closeRows. */)
+	default:
+		return api.processRows(dst, rows, true /* multipleRows. */, false /* closeRows. */)
+	}
+}
+
 // NotFound returns true if err is a not found error.
 // This error is returned by ScanOne if there were no rows.
 func NotFound(err error) bool {
@@ -225,6 +336,10 @@ func NotFound(err error) bool {
 // ErrNotFound is returned by ScanOne if there were no rows.
 var ErrNotFound = errors.New("scany: no row was found")
 
+// ErrUnexpectedRowsAffected is returned by the ExecExpect functions of sqlscan and pgxscan
+// if the query affected a different number of rows than expected.
+var ErrUnexpectedRowsAffected = errors.New("scany: unexpected number of affected rows")
+
 type sliceDestinationMeta struct {
 	val             reflect.Value
 	elementBaseType reflect.Type
@@ -369,6 +484,32 @@ func (api *API) ScanRow(dst interface{}, rows Rows) error {
 	return rs.Scan(dst)
 }
 
+// IsRowsSlice is a package-level helper function that uses the DefaultAPI object.
+// See API.IsRowsSlice for details.
+func IsRowsSlice(dst interface{}) bool {
+	return DefaultAPI.IsRowsSlice(dst)
+}
+
+// IsRowsSlice reports whether dst is a pointer to a slice that holds one element per row,
+// rather than a slice that a single column value is scanned into.
+// That's the case for slices of structs, pointers to structs and maps, unless the element is one of the scannable types.
+// Other slices, e.g. []byte or []string, are what binary and array columns are scanned into.
+// The ExecReturning functions of sqlscan and pgxscan use it to choose between ScanAll and ScanOne.
+func (api *API) IsRowsSlice(dst interface{}) bool {
+	dstType := reflect.TypeOf(dst)
+	if dstType == nil || dstType.Kind() != reflect.Ptr || dstType.Elem().Kind() != reflect.Slice {
+		return false
+	}
+	elementType := dstType.Elem().Elem()
+	if api.isScannableType(elementType) {
+		return false
+	}
+	if elementType.Kind() == reflect.Ptr && elementType.Elem().Kind() == reflect.Struct {
+		elementType = elementType.Elem()
+	}
+	return elementType.Kind() == reflect.Struct || elementType.Kind() == reflect.Map
+}
+
 func (api *API) isScannableType(dstType reflect.Type) bool {
 	dstRefType := reflect.PtrTo(dstType)
 	for _, st := range api.scannableTypesReflect {
@@ -7,7 +7,7 @@ import (
 	"os"
 	"testing"
 
-	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -15,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/internal/testdb"
 )
 
 var (
@@ -396,20 +398,15 @@ func TestScanRow_withAllowUnknownColumns_unknownColumnType(t *testing.T) {
 func TestMain(m *testing.M) {
 	exitCode := func() int {
 		flag.Parse()
-		ts, err := testserver.NewTestServer()
-		if err != nil {
-			panic(err)
-		}
+		// The server is started by the first test that connects to testDB.
+		ts := testdb.Server{Prepare: prepareTestDB}
 		defer ts.Stop()
-		testDB, err = pgxpool.New(ctx, ts.PGURL().String())
+		var err error
+		testDB, err = ts.Pool()
 		if err != nil {
 			panic(err)
 		}
 		defer testDB.Close()
-		err = prepareTestDB(testDB)
-		if err != nil {
-			panic(err)
-		}
 		testAPI, err = getAPI()
 		if err != nil {
 			panic(err)
@@ -419,10 +416,120 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
-func prepareTestDB(testDB *pgxpool.Pool) (err error) {
-	_, err = testDB.Exec(ctx, `
+func prepareTestDB(ctx context.Context, conn *pgx.Conn) (err error) {
+	_, err = conn.Exec(ctx, `
 		CREATE TYPE test_enum_type AS ENUM ('test_val_1', 'test_val_2');
 	`)
 
 	return err
 }
+
+func TestScanAllSets(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(
+		dbscantest.ResultSet{Columns: []string{"foo", "bar"}, Rows: [][]interface{}{{"foo val", "bar val"}, {"foo val 2", "bar val 2"}}},
+		dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"skipped val"}}},
+		dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"foo val"}}},
+	)
+	expected1 := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+	expected3 := "foo val"
+
+	var got1 []*testModel
+	var got3 string
+	err := testAPI.ScanAllSets([]interface{}{&got1, nil, dbscan.One(&got3)}, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected1, got1)
+	assert.Equal(t, expected3, got3)
+	assert.True(t, rows.Closed())
+}
+
+func TestScanAllSets_fewerResultSets_leavesDestinationsUntouched(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(
+		dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"foo val"}}},
+	)
+
+	var got1, got2 []string
+	err := testAPI.ScanAllSets([]interface{}{&got1, &got2}, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"foo val"}, got1)
+	assert.Nil(t, got2)
+}
+
+func TestScanAllSetsStrict_resultSetsNumberMismatch_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		dsts        []interface{}
+		expectedErr string
+	}{
+		{
+			name:        "fewer result sets",
+			dsts:        []interface{}{&[]string{}, &[]string{}, &[]string{}},
+			expectedErr: "scany: expected 3 result sets, got: 2",
+		},
+		{
+			name:        "more result sets",
+			dsts:        []interface{}{&[]string{}},
+			expectedErr: "scany: expected 1 result sets, got more",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := dbscantest.NewFakeRowsSets(
+				dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"foo val"}}},
+				dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"foo val"}}},
+			)
+			err := testAPI.ScanAllSetsStrict(tc.dsts, rows)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestScanAllSetsStrict_oneDestinationNoRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(
+		dbscantest.ResultSet{Columns: []string{"foo"}, Rows: [][]interface{}{{"foo val"}}},
+		dbscantest.ResultSet{Columns: []string{"foo"}},
+	)
+
+	var got1 []string
+	var got2 string
+	err := testAPI.ScanAllSetsStrict([]interface{}{&got1, dbscan.One(&got2)}, rows)
+
+	assert.True(t, dbscan.NotFound(err))
+	assert.EqualError(t, err, "error processing destination 1: scany: no row was found")
+}
+
+func TestIsRowsSlice(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		dst      interface{}
+		expected bool
+	}{
+		{name: "slice of structs", dst: &[]testModel{}, expected: true},
+		{name: "slice of pointers to structs", dst: &[]*testModel{}, expected: true},
+		{name: "slice of maps", dst: &[]map[string]interface{}{}, expected: true},
+		{name: "slice of scannable structs", dst: &[]sql.NullString{}},
+		{name: "slice of strings", dst: &[]string{}},
+		{name: "bytes", dst: &[]byte{}},
+		{name: "slice by value", dst: []testModel{}},
+		{name: "struct", dst: &testModel{}},
+		{name: "nil", dst: nil},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, testAPI.IsRowsSlice(tc.dst))
+		})
+	}
+}
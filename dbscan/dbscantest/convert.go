@@ -0,0 +1,103 @@
+package dbscantest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// convertDB sends every row scanned by FakeRows through database/sql,
+// so values are converted into destinations exactly the same way as (*sql.Rows).Scan does it.
+var convertDB = sql.OpenDB(convertConnector{})
+
+// scanValues converts values of a row with the given columns into dest via database/sql.
+func scanValues(columns []string, values []interface{}, dest []interface{}) error {
+	// The query text carries the column names, so conversion errors mention them.
+	query, err := json.Marshal(columns)
+	if err != nil {
+		return fmt.Errorf("dbscantest: encode columns: %w", err)
+	}
+	rows, err := convertDB.QueryContext(context.Background(), string(query), values...)
+	if err != nil {
+		return fmt.Errorf("dbscantest: convert row: %w", err)
+	}
+	defer rows.Close() //nolint: errcheck
+	if !rows.Next() {
+		return fmt.Errorf("dbscantest: convert row: %w", rows.Err())
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return fmt.Errorf("dbscantest: %w", err)
+	}
+	return nil
+}
+
+type convertConnector struct{}
+
+func (convertConnector) Connect(context.Context) (driver.Conn, error) {
+	return convertConn{}, nil
+}
+
+func (convertConnector) Driver() driver.Driver {
+	return convertDriver{}
+}
+
+type convertDriver struct{}
+
+func (convertDriver) Open(string) (driver.Conn, error) {
+	return convertConn{}, nil
+}
+
+// convertConn answers every query with a single row that consists of the query arguments.
+type convertConn struct{}
+
+var (
+	_ driver.QueryerContext    = convertConn{}
+	_ driver.NamedValueChecker = convertConn{}
+)
+
+var errConvertOnly = errors.New("dbscantest: the connection only converts values")
+
+func (convertConn) Prepare(string) (driver.Stmt, error) { return nil, errConvertOnly }
+
+func (convertConn) Close() error { return nil }
+
+func (convertConn) Begin() (driver.Tx, error) { return nil, errConvertOnly }
+
+// CheckNamedValue passes all values to the driver as is, so the destinations get them unchanged,
+// the same as they would get them from a real driver.
+func (convertConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (convertConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	var columns []string
+	if err := json.Unmarshal([]byte(query), &columns); err != nil {
+		return nil, fmt.Errorf("dbscantest: decode columns: %w", err)
+	}
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return &convertRows{columns: columns, values: values}, nil
+}
+
+type convertRows struct {
+	columns []string
+	values  []driver.Value
+	done    bool
+}
+
+func (cr *convertRows) Columns() []string { return cr.columns }
+
+func (cr *convertRows) Close() error { return nil }
+
+func (cr *convertRows) Next(dest []driver.Value) error {
+	if cr.done {
+		return io.EOF
+	}
+	cr.done = true
+	copy(dest, cr.values)
+	return nil
+}
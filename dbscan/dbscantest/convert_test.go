@@ -0,0 +1,123 @@
+package dbscantest_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+type customString string
+
+func TestFakeRows_Scan_convertsValues(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		name     string
+		src      interface{}
+		dst      interface{}
+		expected interface{}
+	}{
+		{name: "string to string", src: "foo", dst: new(string), expected: "foo"},
+		{name: "bytes to string", src: []byte("foo"), dst: new(string), expected: "foo"},
+		{name: "string to bytes", src: "foo", dst: new([]byte), expected: []byte("foo")},
+		{name: "int64 to int", src: int64(42), dst: new(int), expected: 42},
+		{name: "int64 to string", src: int64(42), dst: new(string), expected: "42"},
+		{name: "string to int32", src: "42", dst: new(int32), expected: int32(42)},
+		{name: "bytes to uint8", src: []byte("42"), dst: new(uint8), expected: uint8(42)},
+		{name: "string to float64", src: "1.5", dst: new(float64), expected: 1.5},
+		{name: "float64 to string", src: 1.5, dst: new(string), expected: "1.5"},
+		{name: "int64 to bool", src: int64(1), dst: new(bool), expected: true},
+		{name: "string to bool", src: "true", dst: new(bool), expected: true},
+		{name: "time to time", src: createdAt, dst: new(time.Time), expected: createdAt},
+		{name: "time to string", src: createdAt, dst: new(string), expected: "2020-01-02T03:04:05Z"},
+		{name: "string to custom string", src: "foo", dst: new(customString), expected: customString("foo")},
+		{name: "string to ptr", src: "foo", dst: new(*string), expected: func() *string { s := "foo"; return &s }()},
+		{name: "int64 to int ptr", src: int64(42), dst: new(*int), expected: func() *int { i := 42; return &i }()},
+		{name: "nil to ptr", src: nil, dst: new(*string), expected: (*string)(nil)},
+		{name: "nil to interface", src: nil, dst: new(interface{}), expected: nil},
+		{name: "int64 to interface", src: int64(42), dst: new(interface{}), expected: int64(42)},
+		{name: "nil to scanner", src: nil, dst: new(sql.NullString), expected: sql.NullString{}},
+		{name: "string to scanner", src: "foo", dst: new(sql.NullString), expected: sql.NullString{String: "foo", Valid: true}},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := scanValue(tc.dst, tc.src)
+			require.NoError(t, err)
+			got := reflectElem(tc.dst)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestFakeRows_Scan_invalidConversion_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		src         interface{}
+		dst         interface{}
+		expectedErr string
+	}{
+		{
+			name:        "NULL to string",
+			src:         nil,
+			dst:         new(string),
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": converting NULL to string is unsupported`,
+		},
+		{
+			name:        "NULL to int",
+			src:         nil,
+			dst:         new(int),
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": converting NULL to int is unsupported`,
+		},
+		{
+			name:        "invalid int",
+			src:         "foo",
+			dst:         new(int),
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": converting driver.Value type string ("foo") to a int: invalid syntax`,
+		},
+		{
+			name:        "int overflow",
+			src:         int64(300),
+			dst:         new(int8),
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": converting driver.Value type int64 ("300") to a int8: value out of range`,
+		},
+		{
+			name:        "time to int",
+			src:         time.Time{},
+			dst:         new(struct{}),
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": unsupported Scan, storing driver.Value type time.Time into type *struct {}`,
+		},
+		{
+			name:        "not a pointer",
+			src:         "foo",
+			dst:         "foo",
+			expectedErr: `dbscantest: sql: Scan error on column index 0, name "foo": destination not a pointer`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := scanValue(tc.dst, tc.src)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func scanValue(dst, src interface{}) error {
+	rows := dbscantest.NewFakeRows([]string{"foo"}, [][]interface{}{{src}})
+	rows.Next()
+	return rows.Scan(dst)
+}
+
+func reflectElem(ptr interface{}) interface{} {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
@@ -0,0 +1,24 @@
+// Package dbscantest provides an in-memory implementation of dbscan.Rows for unit tests.
+/*
+Code that scans rows with dbscan, sqlscan or pgxscan can be tested without a database
+by passing FakeRows instead of real rows:
+
+	rows := dbscantest.NewFakeRows(
+		[]string{"id", "name", "bio"},
+		[][]interface{}{
+			{1, "Bob", nil},
+			{2, "Alice", []byte("Likes Go")},
+		},
+	)
+	var users []*User
+	err := dbscan.ScanAll(&users, rows)
+
+FakeRows.Scan converts values into destinations by passing them through database/sql,
+e.g. an int64 value can be scanned into *int, *string or sql.NullInt64 and a nil value represents NULL.
+Multiple result sets and failures of the underlying database library can be simulated as well,
+see FakeRows for details.
+
+Recorder records queries and their results into a Recording that can be saved as a JSON golden file,
+Expectations.ExpectRecording replays it.
+*/
+package dbscantest
@@ -0,0 +1,340 @@
+package dbscantest
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// TestingT is the subset of *testing.T that Expectations use to report failures.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// ArgMatcher can be passed to Expectation.WithArgs to match an argument by a custom rule.
+type ArgMatcher interface {
+	Match(arg interface{}) bool
+}
+
+// ArgMatcherFunc is a function that implements the ArgMatcher interface.
+type ArgMatcherFunc func(arg interface{}) bool
+
+// Match implements the ArgMatcher.Match method.
+func (f ArgMatcherFunc) Match(arg interface{}) bool {
+	return f(arg)
+}
+
+// AnyArg returns an ArgMatcher that matches any argument.
+func AnyArg() ArgMatcher {
+	return ArgMatcherFunc(func(interface{}) bool { return true })
+}
+
+// Expectations keeps a list of expected queries and matches actual queries against them.
+// Fake queriers in sqlscantest and pgxscantest packages are built on top of it.
+// Expectations is safe for concurrent use.
+type Expectations struct {
+	mu       sync.Mutex
+	expected []*Expectation
+}
+
+// ExpectQuery adds an expectation of a query with exactly the given text.
+func (e *Expectations) ExpectQuery(query string) *Expectation {
+	return e.add(&Expectation{query: query})
+}
+
+// ExpectQueryRegexp adds an expectation of a query that matches the given regular expression.
+// It panics if the expression can't be parsed.
+func (e *Expectations) ExpectQueryRegexp(pattern string) *Expectation {
+	return e.add(&Expectation{query: pattern, re: regexp.MustCompile(pattern)})
+}
+
+func (e *Expectations) add(ex *Expectation) *Expectation {
+	ex.times = 1
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expected = append(e.expected, ex)
+	return ex
+}
+
+// Match finds the first expectation that matches the query and the arguments
+// and still has calls left, and records a call of it.
+// If there is no such expectation, it returns an error that describes
+// how the query differs from the remaining expectations.
+func (e *Expectations) Match(query string, args []interface{}) (*Expectation, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ex := range e.expected {
+		if ex.calls < ex.times && ex.matches(query, args) {
+			ex.calls++
+			return ex, nil
+		}
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "dbscantest: unexpected query:\n%s", formatCall(query, args))
+	for _, ex := range e.expected {
+		if ex.calls >= ex.times {
+			continue
+		}
+		fmt.Fprintf(&sb, "diff with the expected query:\n%s", ex.diff(query, args))
+	}
+	return nil, fmt.Errorf("%s", strings.TrimSuffix(sb.String(), "\n"))
+}
+
+// ExpectationsWereMet returns an error if some of the expected queries weren't made.
+func (e *Expectations) ExpectationsWereMet() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var missing []string
+	for _, ex := range e.expected {
+		if ex.calls < ex.times {
+			missing = append(missing, fmt.Sprintf("%scalled %d out of %d times", ex.describe(), ex.calls, ex.times))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("dbscantest: expected queries weren't made:\n%s", strings.Join(missing, "\n"))
+}
+
+// AssertExpectations reports a test failure if some of the expected queries weren't made.
+func (e *Expectations) AssertExpectations(t TestingT) bool {
+	t.Helper()
+	if err := e.ExpectationsWereMet(); err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	return true
+}
+
+// Expectation describes an expected query and what to return for it.
+// By default, an expectation matches any arguments, returns no rows and is expected to be called once.
+type Expectation struct {
+	query        string
+	re           *regexp.Regexp
+	args         []interface{}
+	argsSet      bool
+	sets         []ResultSet
+	err          error
+	rowsErr      error
+	rowsErrAfter int
+	rowsAffected int64
+	times        int
+	calls        int
+}
+
+// WithArgs makes the expectation match only the given arguments.
+// Arguments are compared with reflect.DeepEqual, []byte arguments by their contents,
+// or with ArgMatcher.Match if an expected argument implements it.
+func (ex *Expectation) WithArgs(args ...interface{}) *Expectation {
+	ex.args = args
+	ex.argsSet = true
+	return ex
+}
+
+// WillReturnRows sets the rows that are returned for the query.
+func (ex *Expectation) WillReturnRows(columns []string, rows [][]interface{}) *Expectation {
+	return ex.WillReturnResultSets(ResultSet{Columns: columns, Rows: rows})
+}
+
+// WillReturnResultSets sets multiple result sets that are returned for the query.
+func (ex *Expectation) WillReturnResultSets(sets ...ResultSet) *Expectation {
+	ex.sets = sets
+	return ex
+}
+
+// WillReturnError makes the query fail with err.
+func (ex *Expectation) WillReturnError(err error) *Expectation {
+	ex.err = err
+	return ex
+}
+
+// WillReturnRowsErr makes iteration of the first result set stop after the given number of rows
+// with err, as if the connection was lost while rows were streamed.
+func (ex *Expectation) WillReturnRowsErr(afterRows int, err error) *Expectation {
+	ex.rowsErrAfter = afterRows
+	ex.rowsErr = err
+	return ex
+}
+
+// WillReturnRowsAffected sets the number of rows affected that is returned for a statement that doesn't return rows.
+func (ex *Expectation) WillReturnRowsAffected(rowsAffected int64) *Expectation {
+	ex.rowsAffected = rowsAffected
+	return ex
+}
+
+// Times sets how many times the query is expected to be made.
+func (ex *Expectation) Times(n int) *Expectation {
+	ex.times = n
+	return ex
+}
+
+// Err returns the error set with WillReturnError.
+func (ex *Expectation) Err() error {
+	return ex.err
+}
+
+// RowsErr returns the error and the number of rows before it set with WillReturnRowsErr.
+func (ex *Expectation) RowsErr() (afterRows int, err error) {
+	return ex.rowsErrAfter, ex.rowsErr
+}
+
+// RowsAffected returns the number of rows set with WillReturnRowsAffected.
+func (ex *Expectation) RowsAffected() int64 {
+	return ex.rowsAffected
+}
+
+// ResultSets returns the result sets set with WillReturnRows or WillReturnResultSets.
+func (ex *Expectation) ResultSets() []ResultSet {
+	return ex.sets
+}
+
+// NewRows returns new FakeRows over the result sets of the expectation.
+func (ex *Expectation) NewRows() *FakeRows {
+	rows := NewFakeRows(nil, nil)
+	if len(ex.sets) > 0 {
+		rows = NewFakeRowsSets(ex.sets...)
+	}
+	if ex.rowsErr != nil {
+		rows.WithErr(0, ex.rowsErrAfter, ex.rowsErr)
+	}
+	return rows
+}
+
+func (ex *Expectation) matches(query string, args []interface{}) bool {
+	if ex.re != nil {
+		if !ex.re.MatchString(query) {
+			return false
+		}
+	} else if ex.query != query {
+		return false
+	}
+	if !ex.argsSet {
+		return true
+	}
+	if len(ex.args) != len(args) {
+		return false
+	}
+	for i, expected := range ex.args {
+		if m, ok := expected.(ArgMatcher); ok {
+			if !m.Match(args[i]) {
+				return false
+			}
+			continue
+		}
+		if !argsEqual(expected, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ex *Expectation) describe() string {
+	var sb strings.Builder
+	if ex.re != nil {
+		fmt.Fprintf(&sb, "query matching: %s\n", ex.query)
+	} else {
+		fmt.Fprintf(&sb, "query: %s\n", ex.query)
+	}
+	if ex.argsSet {
+		sb.WriteString(formatArgs(ex.args))
+	} else {
+		sb.WriteString("args: any\n")
+	}
+	return sb.String()
+}
+
+func (ex *Expectation) diff(query string, args []interface{}) string {
+	expected := ex.describe()
+	actual := formatCall(query, args)
+	if ex.re != nil && ex.re.MatchString(query) {
+		actual = strings.Replace(actual, "query: "+query, "query matching: "+ex.query, 1)
+	}
+	if !ex.argsSet {
+		actual = strings.Replace(actual, formatArgs(args), "args: any\n", 1)
+	}
+	return diffLines(expected, actual)
+}
+
+// diffLines returns a line by line diff of two texts, lines are prefixed with "-" if they are only in expected,
+// with "+" if they are only in actual and with " " if they are in both.
+// Texts are as short as a query with its arguments, so the longest common subsequence is found the simple way.
+func diffLines(expected, actual string) string {
+	a := strings.Split(strings.TrimSuffix(expected, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(actual, "\n"), "\n")
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var sb strings.Builder
+	sb.WriteString("--- Expected\n+++ Actual\n")
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&sb, " %s\n", a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&sb, "-%s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&sb, "+%s\n", b[j])
+			j++
+		}
+	}
+	return sb.String()
+}
+
+// argsEqual reports whether an actual argument equals the expected one.
+// []byte arguments are compared by their contents.
+func argsEqual(expected, actual interface{}) bool {
+	expectedBytes, ok := expected.([]byte)
+	if !ok {
+		return reflect.DeepEqual(expected, actual)
+	}
+	actualBytes, ok := actual.([]byte)
+	if !ok {
+		return false
+	}
+	if expectedBytes == nil || actualBytes == nil {
+		return expectedBytes == nil && actualBytes == nil
+	}
+	return bytes.Equal(expectedBytes, actualBytes)
+}
+
+func formatCall(query string, args []interface{}) string {
+	return fmt.Sprintf("query: %s\n%s", query, formatArgs(args))
+}
+
+func formatArgs(args []interface{}) string {
+	var sb strings.Builder
+	sb.WriteString("args:\n")
+	for i, arg := range args {
+		if _, ok := arg.(ArgMatcher); ok {
+			if s, ok := arg.(fmt.Stringer); ok {
+				fmt.Fprintf(&sb, "  %d: %s\n", i, s)
+			} else {
+				fmt.Fprintf(&sb, "  %d: matcher %s\n", i, reflect.TypeOf(arg))
+			}
+			continue
+		}
+		fmt.Fprintf(&sb, "  %d: %#v\n", i, arg)
+	}
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package dbscantest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+func TestExpectation_WithArgs(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		expected []interface{}
+		actual   []interface{}
+		matches  bool
+	}{
+		{
+			name:     "equal args",
+			expected: []interface{}{1, "foo", []byte("bar")},
+			actual:   []interface{}{1, "foo", []byte("bar")},
+			matches:  true,
+		},
+		{
+			name:     "different bytes",
+			expected: []interface{}{[]byte("bar")},
+			actual:   []interface{}{[]byte("baz")},
+		},
+		{
+			name:     "bytes and string",
+			expected: []interface{}{[]byte("bar")},
+			actual:   []interface{}{"bar"},
+		},
+		{
+			name:     "nil and empty bytes",
+			expected: []interface{}{[]byte(nil)},
+			actual:   []interface{}{[]byte{}},
+		},
+		{
+			name:     "different types",
+			expected: []interface{}{1},
+			actual:   []interface{}{int64(1)},
+		},
+		{
+			name:     "matcher",
+			expected: []interface{}{dbscantest.AnyArg()},
+			actual:   []interface{}{"foo"},
+			matches:  true,
+		},
+		{
+			name:     "different number of args",
+			expected: []interface{}{1},
+			actual:   []interface{}{1, 2},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var e dbscantest.Expectations
+			e.ExpectQuery(`SELECT 1`).WithArgs(tc.expected...)
+
+			_, err := e.Match(`SELECT 1`, tc.actual)
+
+			assert.Equal(t, tc.matches, err == nil, "error: %v", err)
+		})
+	}
+}
+
+func TestExpectations_Match_unexpectedQuery_returnsErrWithDiff(t *testing.T) {
+	t.Parallel()
+	var e dbscantest.Expectations
+	e.ExpectQuery(`SELECT id FROM users WHERE name = $1 AND age = $2`).WithArgs("Bob", 42)
+
+	_, err := e.Match(`SELECT id FROM users WHERE name = $1`, []interface{}{"Bob"})
+
+	expectedErr := "dbscantest: unexpected query:\n" +
+		"query: SELECT id FROM users WHERE name = $1\n" +
+		"args:\n" +
+		"  0: \"Bob\"\n" +
+		"diff with the expected query:\n" +
+		"--- Expected\n" +
+		"+++ Actual\n" +
+		"-query: SELECT id FROM users WHERE name = $1 AND age = $2\n" +
+		"+query: SELECT id FROM users WHERE name = $1\n" +
+		" args:\n" +
+		"   0: \"Bob\"\n" +
+		"-  1: 42"
+	assert.EqualError(t, err, expectedErr)
+}
@@ -0,0 +1,195 @@
+package dbscantest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// ResultSet is a single result set of FakeRows.
+type ResultSet struct {
+	Columns []string
+	Rows    [][]interface{}
+	// ColumnTypes is optional, if set, it's returned by FakeRows.ColumnScanTypes.
+	ColumnTypes []reflect.Type
+}
+
+// ErrRowsClosed is returned by FakeRows methods called after the rows are closed.
+var ErrRowsClosed = errors.New("dbscantest: rows are closed")
+
+// FakeRows is an in-memory implementation of the dbscan.Rows interface.
+// It iterates over predefined result sets and scans values with database/sql conversion rules.
+// Errors can be injected to simulate failures, see WithErr, WithScanErr, WithColumnsErr and WithCloseErr.
+// FakeRows isn't safe for concurrent use.
+type FakeRows struct {
+	sets      []ResultSet
+	set       int
+	row       int
+	closed    bool
+	iterErr   error
+	errAfter  int
+	errSet    int
+	errHit    bool
+	scanErrs  map[[2]int]error
+	colsErr   error
+	closeErr  error
+	closeCall int
+}
+
+var (
+	_ dbscan.Rows            = &FakeRows{}
+	_ dbscan.ColumnScanTyper = &FakeRows{}
+)
+
+// NewFakeRows returns FakeRows with a single result set.
+func NewFakeRows(columns []string, rows [][]interface{}) *FakeRows {
+	return NewFakeRowsSets(ResultSet{Columns: columns, Rows: rows})
+}
+
+// NewFakeRowsSets returns FakeRows with multiple result sets,
+// use NextResultSet to move between them.
+func NewFakeRowsSets(sets ...ResultSet) *FakeRows {
+	return &FakeRows{sets: sets, row: -1}
+}
+
+// WithErr makes iteration of the result set with the given index stop after the given number of rows
+// with Err returning err, as if the database connection was lost.
+func (fr *FakeRows) WithErr(set, afterRows int, err error) *FakeRows {
+	fr.iterErr = err
+	fr.errSet = set
+	fr.errAfter = afterRows
+	return fr
+}
+
+// WithScanErr makes Scan return err for the row with the given index of the result set with the given index.
+func (fr *FakeRows) WithScanErr(set, row int, err error) *FakeRows {
+	if fr.scanErrs == nil {
+		fr.scanErrs = make(map[[2]int]error)
+	}
+	fr.scanErrs[[2]int{set, row}] = err
+	return fr
+}
+
+// WithColumnsErr makes Columns return err.
+func (fr *FakeRows) WithColumnsErr(err error) *FakeRows {
+	fr.colsErr = err
+	return fr
+}
+
+// WithCloseErr makes Close return err.
+func (fr *FakeRows) WithCloseErr(err error) *FakeRows {
+	fr.closeErr = err
+	return fr
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (fr *FakeRows) Columns() ([]string, error) {
+	if fr.colsErr != nil {
+		return nil, fr.colsErr
+	}
+	if fr.closed {
+		return nil, ErrRowsClosed
+	}
+	if fr.set >= len(fr.sets) {
+		return nil, fmt.Errorf("dbscantest: no result set available")
+	}
+	return fr.sets[fr.set].Columns, nil
+}
+
+// ColumnScanTypes implements the dbscan.ColumnScanTyper.ColumnScanTypes method.
+// It returns ResultSet.ColumnTypes, or nil types if they aren't set.
+func (fr *FakeRows) ColumnScanTypes() ([]reflect.Type, error) {
+	columns, err := fr.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if types := fr.sets[fr.set].ColumnTypes; types != nil {
+		return types, nil
+	}
+	return make([]reflect.Type, len(columns)), nil
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (fr *FakeRows) Next() bool {
+	if fr.closed || fr.set >= len(fr.sets) {
+		return false
+	}
+	if fr.iterErr != nil && fr.set == fr.errSet && fr.row+1 >= fr.errAfter {
+		// Rows are closed automatically when iteration stops with an error, the same as *sql.Rows do.
+		fr.errHit = true
+		fr.closed = true
+		return false
+	}
+	fr.row++
+	return fr.row < len(fr.sets[fr.set].Rows)
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+// It converts each value of the current row into the corresponding destination via database/sql,
+// so the same rules apply as for *sql.Rows.
+func (fr *FakeRows) Scan(dest ...interface{}) error {
+	if fr.closed {
+		return ErrRowsClosed
+	}
+	if fr.set >= len(fr.sets) || fr.row < 0 || fr.row >= len(fr.sets[fr.set].Rows) {
+		return fmt.Errorf("dbscantest: Scan called without calling Next")
+	}
+	if err, ok := fr.scanErrs[[2]int{fr.set, fr.row}]; ok {
+		return err
+	}
+	values := fr.sets[fr.set].Rows[fr.row]
+	if len(dest) != len(values) {
+		return fmt.Errorf("dbscantest: expected %d destination arguments in Scan, not %d", len(values), len(dest))
+	}
+	return scanValues(fr.sets[fr.set].Columns, values, dest)
+}
+
+// Values returns the values of the current row as they were provided, without any conversions.
+func (fr *FakeRows) Values() ([]interface{}, error) {
+	if fr.closed {
+		return nil, ErrRowsClosed
+	}
+	if fr.set >= len(fr.sets) || fr.row < 0 || fr.row >= len(fr.sets[fr.set].Rows) {
+		return nil, fmt.Errorf("dbscantest: Values called without calling Next")
+	}
+	return fr.sets[fr.set].Rows[fr.row], nil
+}
+
+// Err implements the dbscan.Rows.Err method.
+// It returns the error injected with WithErr once the iteration reaches it.
+func (fr *FakeRows) Err() error {
+	if fr.errHit {
+		return fr.iterErr
+	}
+	return nil
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+func (fr *FakeRows) NextResultSet() bool {
+	if fr.closed || fr.set >= len(fr.sets) {
+		return false
+	}
+	fr.set++
+	fr.row = -1
+	return fr.set < len(fr.sets)
+}
+
+// Close implements the dbscan.Rows.Close method.
+// Calling it multiple times is allowed, the same as for *sql.Rows.
+func (fr *FakeRows) Close() error {
+	fr.closed = true
+	fr.closeCall++
+	return fr.closeErr
+}
+
+// Closed reports whether Close was called or the iteration was stopped by an injected error.
+func (fr *FakeRows) Closed() bool {
+	return fr.closed
+}
+
+// CloseCalls returns the number of times Close was called.
+func (fr *FakeRows) CloseCalls() int {
+	return fr.closeCall
+}
@@ -0,0 +1,142 @@
+package dbscantest_test
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+type user struct {
+	ID        int
+	Name      string
+	Bio       *string
+	Age       sql.NullInt64
+	CreatedAt time.Time
+}
+
+func TestFakeRows_ScanAll(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := dbscantest.NewFakeRows(
+		[]string{"id", "name", "bio", "age", "created_at"},
+		[][]interface{}{
+			{int64(1), []byte("Bob"), "Likes Go", "42", createdAt},
+			{"2", "Alice", nil, nil, createdAt},
+		},
+	)
+	bio := "Likes Go"
+	expected := []*user{
+		{ID: 1, Name: "Bob", Bio: &bio, Age: sql.NullInt64{Int64: 42, Valid: true}, CreatedAt: createdAt},
+		{ID: 2, Name: "Alice", CreatedAt: createdAt},
+	}
+
+	var got []*user
+	err := dbscan.ScanAll(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	assert.True(t, rows.Closed())
+}
+
+func TestFakeRows_ScanAllSets(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(
+		dbscantest.ResultSet{Columns: []string{"name"}, Rows: [][]interface{}{{"Bob"}, {"Alice"}}},
+		dbscantest.ResultSet{Columns: []string{"count"}, Rows: [][]interface{}{{int64(2)}}},
+	)
+
+	var names []string
+	var count int
+	err := dbscan.ScanAllSetsStrict([]interface{}{&names, dbscan.One(&count)}, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Bob", "Alice"}, names)
+	assert.Equal(t, 2, count)
+}
+
+func TestFakeRows_columnTypes(t *testing.T) {
+	t.Parallel()
+	api, err := dbscan.NewAPI(dbscan.WithColumnTypedMaps(true))
+	require.NoError(t, err)
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns:     []string{"id", "name"},
+		Rows:        [][]interface{}{{[]byte("1"), nil}},
+		ColumnTypes: []reflect.Type{reflect.TypeOf(int64(0)), reflect.TypeOf("")},
+	})
+
+	var got map[string]interface{}
+	err = api.ScanOne(&got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"id": int64(1), "name": nil}, got)
+}
+
+func TestFakeRows_injectedErrors(t *testing.T) {
+	t.Parallel()
+	injectedErr := errors.New("injected error")
+	newRows := func() *dbscantest.FakeRows {
+		return dbscantest.NewFakeRows([]string{"name"}, [][]interface{}{{"Bob"}, {"Alice"}})
+	}
+	cases := []struct {
+		name        string
+		rows        *dbscantest.FakeRows
+		expectedErr string
+	}{
+		{
+			name:        "rows error",
+			rows:        newRows().WithErr(0, 1, injectedErr),
+			expectedErr: "scany: rows final error: injected error",
+		},
+		{
+			name:        "scan error",
+			rows:        newRows().WithScanErr(0, 1, injectedErr),
+			expectedErr: "scanning: scanning: doing scan: scanFn: scany: scan row value into a primitive type: injected error",
+		},
+		{
+			name:        "columns error",
+			rows:        newRows().WithColumnsErr(injectedErr),
+			expectedErr: "scanning: scanning: doing scan: starting: scany: get rows columns: injected error",
+		},
+		{
+			name:        "close error",
+			rows:        newRows().WithCloseErr(injectedErr),
+			expectedErr: "scany: close rows after processing: injected error",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			err := dbscan.ScanAll(&got, tc.rows)
+			assert.EqualError(t, err, tc.expectedErr)
+			assert.ErrorIs(t, err, injectedErr)
+			assert.True(t, tc.rows.Closed())
+		})
+	}
+}
+
+func TestFakeRows_Scan_invalidUsage_returnsErr(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRows([]string{"name"}, [][]interface{}{{"Bob"}})
+	var name string
+
+	err := rows.Scan(&name)
+	assert.EqualError(t, err, "dbscantest: Scan called without calling Next")
+
+	require.True(t, rows.Next())
+	err = rows.Scan(&name, &name)
+	assert.EqualError(t, err, "dbscantest: expected 1 destination arguments in Scan, not 2")
+
+	require.NoError(t, rows.Close())
+	err = rows.Scan(&name)
+	assert.ErrorIs(t, err, dbscantest.ErrRowsClosed)
+}
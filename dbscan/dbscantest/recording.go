@@ -0,0 +1,483 @@
+package dbscantest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Recording is the content of a golden file with recorded queries and their results.
+// It's created by Recorder and replayed with Expectations.ExpectRecording.
+type Recording struct {
+	Queries []*RecordedQuery `json:"queries"`
+}
+
+// RecordedQuery is a single query with its arguments and results.
+type RecordedQuery struct {
+	Query        string              `json:"query"`
+	Args         []Value             `json:"args"`
+	ResultSets   []RecordedResultSet `json:"result_sets,omitempty"`
+	RowsAffected int64               `json:"rows_affected,omitempty"`
+	Error        string              `json:"error,omitempty"`
+}
+
+// RecordedResultSet is a single result set of a recorded query.
+type RecordedResultSet struct {
+	Columns []string  `json:"columns"`
+	Rows    [][]Value `json:"rows"`
+}
+
+// Value wraps a normalized value, see NormalizeValue, and serializes it to JSON
+// so it's decoded back to the same Go type. Strings, booleans and nil are represented as JSON values as is,
+// other types are represented as objects with a single key holding the type name, e.g. {"int64": "1"}.
+type Value struct {
+	V interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (v Value) MarshalJSON() ([]byte, error) {
+	var typ string
+	var value interface{}
+	switch x := v.V.(type) {
+	case nil, string, bool:
+		return marshalJSON(x)
+	case int64:
+		typ, value = "int64", strconv.FormatInt(x, 10)
+	case float64:
+		typ, value = "float64", strconv.FormatFloat(x, 'g', -1, 64)
+	case []byte:
+		typ, value = "bytes", base64.StdEncoding.EncodeToString(x)
+	case time.Time:
+		typ, value = "time", x.Format(time.RFC3339Nano)
+	default:
+		typ, value = "json", x
+	}
+	return marshalJSON(map[string]interface{}{typ: value})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	typed, ok := raw.(map[string]interface{})
+	if !ok {
+		v.V = raw
+		return nil
+	}
+	if len(typed) != 1 {
+		return fmt.Errorf("dbscantest: typed value must have exactly one key, got: %d", len(typed))
+	}
+	for typ, value := range typed {
+		if typ == "json" {
+			v.V = value
+			return nil
+		}
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("dbscantest: %s value must be a string, got: %T", typ, value)
+		}
+		var err error
+		switch typ {
+		case "int64":
+			v.V, err = strconv.ParseInt(s, 10, 64)
+		case "float64":
+			v.V, err = strconv.ParseFloat(s, 64)
+		case "bytes":
+			v.V, err = base64.StdEncoding.DecodeString(s)
+		case "time":
+			v.V, err = time.Parse(time.RFC3339Nano, s)
+		default:
+			err = fmt.Errorf("dbscantest: unknown value type: %q", typ)
+		}
+		return err
+	}
+	return nil
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Keep query text readable in golden files.
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// NormalizeValue converts a value to one of the types that can be recorded:
+// nil, int64, float64, bool, string, []byte, time.Time,
+// or a JSON compatible composition of them for slices, arrays, maps and structs.
+// Pointers are dereferenced and driver.Valuer implementations are replaced with their values.
+func NormalizeValue(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case nil, int64, float64, bool, string, time.Time:
+		return x, nil
+	case []byte:
+		return cloneBytes(x), nil
+	case driver.Valuer:
+		rv := reflect.ValueOf(x)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return nil, nil
+		}
+		value, err := x.Value()
+		if err != nil {
+			return nil, fmt.Errorf("dbscantest: get value of %T: %w", v, err)
+		}
+		if _, ok := value.(driver.Valuer); ok {
+			return nil, fmt.Errorf("dbscantest: value of %T is driver.Valuer again", v)
+		}
+		return NormalizeValue(value)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return NormalizeValue(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := rv.Uint()
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("dbscantest: value %d of %T overflows int64", u, v)
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Bool:
+		return rv.Bool(), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			return cloneBytes(rv.Bytes()), nil
+		}
+		// Composite values are kept in their JSON representation.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("dbscantest: can't record value of type %T: %w", v, err)
+		}
+		var result interface{}
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("dbscantest: can't record value of type %T: %w", v, err)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("dbscantest: can't record value of type %T", v)
+}
+
+func normalizeValues(values []interface{}) ([]Value, error) {
+	result := make([]Value, len(values))
+	for i, v := range values {
+		nv, err := NormalizeValue(v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = Value{V: nv}
+	}
+	return result, nil
+}
+
+// Recorder collects queries with their results and saves them as a golden file.
+// Recording queriers in sqlscantest and pgxscantest packages are built on top of it.
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	recording Recording
+}
+
+// NewRecorder returns a new empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// RecordQuery records a query that returned the result sets or failed with err.
+func (r *Recorder) RecordQuery(query string, args []interface{}, sets []ResultSet, queryErr error) error {
+	rq, err := newRecordedQuery(query, args, queryErr)
+	if err != nil {
+		return err
+	}
+	for _, set := range sets {
+		rs := RecordedResultSet{Columns: set.Columns, Rows: make([][]Value, len(set.Rows))}
+		for i, row := range set.Rows {
+			if rs.Rows[i], err = normalizeValues(row); err != nil {
+				return fmt.Errorf("column values: %w", err)
+			}
+		}
+		rq.ResultSets = append(rq.ResultSets, rs)
+	}
+	r.add(rq)
+	return nil
+}
+
+// RecordExec records a statement that doesn't return rows.
+func (r *Recorder) RecordExec(query string, args []interface{}, rowsAffected int64, execErr error) error {
+	rq, err := newRecordedQuery(query, args, execErr)
+	if err != nil {
+		return err
+	}
+	rq.RowsAffected = rowsAffected
+	r.add(rq)
+	return nil
+}
+
+func newRecordedQuery(query string, args []interface{}, queryErr error) (*RecordedQuery, error) {
+	normalizedArgs, err := normalizeValues(args)
+	if err != nil {
+		return nil, fmt.Errorf("args: %w", err)
+	}
+	rq := &RecordedQuery{Query: query, Args: normalizedArgs}
+	if queryErr != nil {
+		rq.Error = queryErr.Error()
+	}
+	return rq, nil
+}
+
+func (r *Recorder) add(rq *RecordedQuery) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.recording.Queries = append(r.recording.Queries, rq)
+}
+
+// RecordRows wraps rows, so the raw values of the rows scanned from them are recorded as results of the query,
+// the same values as the recording queriers of sqlscantest and pgxscantest record.
+// If rows have the Values() ([]interface{}, error) method, as pgx.Rows and FakeRows do, its result is recorded.
+// Otherwise, rows are considered to be backed by *sql.Rows: driver values are read into interface{} values
+// and converted into the destinations via database/sql.
+// The result is recorded when the rows are closed.
+// Only rows that were scanned are recorded, so rows must be iterated to the end.
+func (r *Recorder) RecordRows(query string, args []interface{}, rows dbscan.Rows) *RecordingRows {
+	return &RecordingRows{Rows: rows, recorder: r, query: query, args: args}
+}
+
+// Recording returns the queries recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Recording{Queries: append([]*RecordedQuery(nil), r.recording.Queries...)}
+}
+
+// Save writes the recorded queries as a golden file, creating the parent directories if needed.
+func (r *Recorder) Save(path string) error {
+	data, err := marshalJSON(r.Recording())
+	if err != nil {
+		return fmt.Errorf("dbscantest: marshal recording: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, data, "", "  "); err != nil {
+		return fmt.Errorf("dbscantest: marshal recording: %w", err)
+	}
+	data = buf.Bytes()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("dbscantest: create golden file directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil { //nolint: gosec
+		return fmt.Errorf("dbscantest: write golden file: %w", err)
+	}
+	return nil
+}
+
+// LoadRecording reads a golden file saved by Recorder.Save.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("dbscantest: read golden file: %w", err)
+	}
+	var recording Recording
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("dbscantest: unmarshal golden file %s: %w", path, err)
+	}
+	return &recording, nil
+}
+
+// ExpectRecording adds an expectation for every recorded query, in the recorded order.
+// Each expectation matches the exact query text and arguments that are equal to the recorded ones
+// after normalization, see NormalizeValue, and returns the recorded results.
+// Recorded errors are returned as errors with the same text.
+func (e *Expectations) ExpectRecording(recording *Recording) {
+	for _, rq := range recording.Queries {
+		args := make([]interface{}, len(rq.Args))
+		for i, arg := range rq.Args {
+			args[i] = recordedArg{value: arg.V}
+		}
+		ex := e.ExpectQuery(rq.Query).WithArgs(args...).WillReturnRowsAffected(rq.RowsAffected)
+		if rq.Error != "" {
+			ex.WillReturnError(errors.New(rq.Error))
+		}
+		sets := make([]ResultSet, len(rq.ResultSets))
+		for i, rs := range rq.ResultSets {
+			sets[i] = ResultSet{Columns: rs.Columns, Rows: make([][]interface{}, len(rs.Rows))}
+			for j, row := range rs.Rows {
+				values := make([]interface{}, len(row))
+				for k, v := range row {
+					values[k] = v.V
+				}
+				sets[i].Rows[j] = values
+			}
+		}
+		ex.WillReturnResultSets(sets...)
+	}
+}
+
+// recordedArg matches an argument that is equal to the recorded one after normalization.
+type recordedArg struct {
+	value interface{}
+}
+
+func (ra recordedArg) Match(arg interface{}) bool {
+	normalized, err := NormalizeValue(arg)
+	if err != nil {
+		return false
+	}
+	// Compare JSON representations, since it's how values are stored in golden files.
+	expected, err := json.Marshal(Value{V: ra.value})
+	if err != nil {
+		return false
+	}
+	actual, err := json.Marshal(Value{V: normalized})
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(expected, actual)
+}
+
+func (ra recordedArg) String() string {
+	return fmt.Sprintf("%#v", ra.value)
+}
+
+// RecordingRows wraps dbscan.Rows and records scanned values, see Recorder.RecordRows.
+type RecordingRows struct {
+	dbscan.Rows
+	recorder *Recorder
+	query    string
+	args     []interface{}
+	sets     []ResultSet
+	started  bool
+	recorded bool
+	err      error
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (rr *RecordingRows) Scan(dest ...interface{}) error {
+	values, err := rr.scanRaw(dest)
+	if err != nil {
+		return err
+	}
+	rr.startSet()
+	for i, v := range values {
+		nv, err := NormalizeValue(v)
+		if err != nil {
+			rr.err = err
+			return fmt.Errorf("recording: %w", err)
+		}
+		values[i] = nv
+	}
+	set := &rr.sets[len(rr.sets)-1]
+	set.Rows = append(set.Rows, values)
+	return nil
+}
+
+// valuesRows is implemented by rows that expose the raw values of the current row, e.g. pgx.Rows.
+type valuesRows interface {
+	Values() ([]interface{}, error)
+}
+
+// scanRaw scans the current row into dest and returns its raw values.
+func (rr *RecordingRows) scanRaw(dest []interface{}) ([]interface{}, error) {
+	if vr, ok := rr.Rows.(valuesRows); ok {
+		if err := rr.Rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		values, err := vr.Values()
+		if err != nil {
+			return nil, fmt.Errorf("dbscantest: recording: get row values: %w", err)
+		}
+		return values, nil
+	}
+	columns, err := rr.Rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(dest))
+	scans := make([]interface{}, len(dest))
+	for i := range values {
+		scans[i] = &values[i]
+	}
+	if err := rr.Rows.Scan(scans...); err != nil {
+		return nil, err
+	}
+	if err := scanValues(columns, values, dest); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+func (rr *RecordingRows) NextResultSet() bool {
+	rr.startSet()
+	if !rr.Rows.NextResultSet() {
+		return false
+	}
+	rr.addSet()
+	return true
+}
+
+// Close implements the dbscan.Rows.Close method.
+// It records the query with the values scanned so far.
+func (rr *RecordingRows) Close() error {
+	rr.startSet()
+	closeErr := rr.Rows.Close()
+	if rr.recorded {
+		return closeErr
+	}
+	rr.recorded = true
+	queryErr := rr.Rows.Err()
+	if queryErr == nil {
+		queryErr = closeErr
+	}
+	if err := rr.recorder.RecordQuery(rr.query, rr.args, rr.sets, queryErr); err != nil && rr.err == nil {
+		rr.err = err
+	}
+	if rr.err != nil {
+		return fmt.Errorf("dbscantest: recording: %w", rr.err)
+	}
+	return closeErr
+}
+
+func (rr *RecordingRows) startSet() {
+	if !rr.started {
+		rr.started = true
+		rr.addSet()
+	}
+}
+
+func (rr *RecordingRows) addSet() {
+	// Columns are recorded even for result sets that aren't scanned.
+	columns, _ := rr.Rows.Columns()
+	rr.sets = append(rr.sets, ResultSet{Columns: columns})
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
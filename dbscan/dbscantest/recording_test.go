@@ -0,0 +1,207 @@
+package dbscantest_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRecorder_RecordRows(t *testing.T) {
+	t.Parallel()
+	goldenPath := filepath.Join("testdata", "recording.json")
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	query := `SELECT id, name, bio, created_at FROM users WHERE id > $1`
+	realRows := dbscantest.NewFakeRows(
+		[]string{"id", "name", "bio", "created_at"},
+		[][]interface{}{
+			{int64(1), "Bob", nil, createdAt},
+			{int64(2), "Alice", "Likes Go", createdAt},
+		},
+	)
+	type user struct {
+		ID        int
+		Name      string
+		Bio       sql.NullString
+		CreatedAt time.Time
+	}
+	rec := dbscantest.NewRecorder()
+
+	var recorded []*user
+	err := dbscan.ScanAll(&recorded, rec.RecordRows(query, []interface{}{0}, realRows))
+	require.NoError(t, err)
+	err = rec.RecordExec(`DELETE FROM users`, nil, 2, nil)
+	require.NoError(t, err)
+	err = rec.RecordQuery(`SELECT broken`, nil, nil, errors.New("syntax error"))
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, rec.Save(goldenPath))
+	}
+	expected, err := os.ReadFile(goldenPath)
+	require.NoError(t, err)
+	savedPath := filepath.Join(t.TempDir(), "recording.json")
+	require.NoError(t, rec.Save(savedPath))
+	got, err := os.ReadFile(savedPath)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(got))
+
+	recording, err := dbscantest.LoadRecording(goldenPath)
+	require.NoError(t, err)
+	var e dbscantest.Expectations
+	e.ExpectRecording(recording)
+
+	ex, err := e.Match(query, []interface{}{0})
+	require.NoError(t, err)
+	var replayed []*user
+	err = dbscan.ScanAll(&replayed, ex.NewRows())
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	ex, err = e.Match(`DELETE FROM users`, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), ex.RowsAffected())
+
+	ex, err = e.Match(`SELECT broken`, nil)
+	require.NoError(t, err)
+	assert.EqualError(t, ex.Err(), "syntax error")
+	assert.NoError(t, e.ExpectationsWereMet())
+}
+
+// upperName is an sql.Scanner that isn't a driver.Valuer, so only the raw value can be replayed into it.
+type upperName struct {
+	Name string
+}
+
+func (un *upperName) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T", src)
+	}
+	un.Name = strings.ToUpper(s)
+	return nil
+}
+
+// sqlRows hides FakeRows.Values, as *sql.Rows don't have it.
+type sqlRows struct {
+	dbscan.Rows
+}
+
+func TestRecorder_RecordRows_recordsRawValues(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name    string
+		newRows func(rows *dbscantest.FakeRows) dbscan.Rows
+	}{
+		{
+			name:    "rows with values",
+			newRows: func(rows *dbscantest.FakeRows) dbscan.Rows { return rows },
+		},
+		{
+			name:    "database/sql rows",
+			newRows: func(rows *dbscantest.FakeRows) dbscan.Rows { return sqlRows{Rows: rows} },
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			type user struct {
+				ID   int
+				Name upperName
+			}
+			api, err := dbscan.NewAPI(dbscan.WithAllowUnknownColumns(true))
+			require.NoError(t, err)
+			query := `SELECT id, name, extra FROM users`
+			realRows := dbscantest.NewFakeRows(
+				[]string{"id", "name", "extra"},
+				[][]interface{}{{int64(1), "bob", "extra val"}},
+			)
+			rec := dbscantest.NewRecorder()
+
+			var recorded []*user
+			err = api.ScanAll(&recorded, rec.RecordRows(query, nil, tc.newRows(realRows)))
+			require.NoError(t, err)
+
+			recording := rec.Recording()
+			require.Len(t, recording.Queries, 1)
+			assert.Equal(t, [][]dbscantest.Value{{{V: int64(1)}, {V: "bob"}, {V: "extra val"}}},
+				recording.Queries[0].ResultSets[0].Rows)
+			var e dbscantest.Expectations
+			e.ExpectRecording(recording)
+			ex, err := e.Match(query, nil)
+			require.NoError(t, err)
+			var replayed []*user
+			err = api.ScanAll(&replayed, ex.NewRows())
+			require.NoError(t, err)
+			assert.Equal(t, []*user{{ID: 1, Name: upperName{Name: "BOB"}}}, replayed)
+			assert.Equal(t, recorded, replayed)
+		})
+	}
+}
+
+func TestExpectations_ExpectRecording_changedArgs_returnsErr(t *testing.T) {
+	t.Parallel()
+	rec := dbscantest.NewRecorder()
+	err := rec.RecordQuery(`SELECT name FROM users WHERE id = $1`, []interface{}{int32(1)}, nil, nil)
+	require.NoError(t, err)
+	var e dbscantest.Expectations
+	e.ExpectRecording(rec.Recording())
+
+	_, err = e.Match(`SELECT name FROM users WHERE id = $1`, []interface{}{2})
+
+	expectedErr := "dbscantest: unexpected query:\n" +
+		"query: SELECT name FROM users WHERE id = $1\n" +
+		"args:\n" +
+		"  0: 2\n" +
+		"diff with the expected query:\n" +
+		"--- Expected\n" +
+		"+++ Actual\n" +
+		" query: SELECT name FROM users WHERE id = $1\n" +
+		" args:\n" +
+		"-  0: 1\n" +
+		"+  0: 2"
+	assert.EqualError(t, err, expectedErr)
+
+	// Arguments of different integer types are equal after normalization.
+	_, err = e.Match(`SELECT name FROM users WHERE id = $1`, []interface{}{1})
+	assert.NoError(t, err)
+}
+
+func TestValue_JSON(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("", 3600))
+	values := []dbscantest.Value{
+		{V: nil}, {V: int64(1) << 60}, {V: 1.5}, {V: true}, {V: "foo"}, {V: []byte{0, 1}}, {V: createdAt},
+		{V: map[string]interface{}{"foo": []interface{}{"bar"}}},
+	}
+
+	data, err := json.Marshal(values)
+	require.NoError(t, err)
+	var got []dbscantest.Value
+	err = json.Unmarshal(data, &got)
+	require.NoError(t, err)
+
+	require.Len(t, got, len(values))
+	for i := range values {
+		if tm, ok := values[i].V.(time.Time); ok {
+			assert.True(t, tm.Equal(got[i].V.(time.Time)))
+			continue
+		}
+		assert.Equal(t, values[i], got[i])
+	}
+}
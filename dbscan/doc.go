@@ -175,6 +175,43 @@ Map type isn't limited to map[string]interface{},
 it can be any map with a string key, e.g., map[string]string or map[string]int,
 if all column values have the same specific type.
 
+When iterating rows manually with RowScanner, the same map can be passed for every row,
+dbscan reuses its internal buffers, so scanning doesn't allocate per column.
+See WithClearMapDestination and WithMapBytesToString options for fine-tuning of the map scanning.
+
+Values of a map[string]interface{} destination have whatever types the database library picks,
+which can differ between databases and drivers.
+With WithColumnTypedMaps option dbscan scans each column into a Go type derived from the column type instead,
+e.g. string, int64, float64, bool or time.Time, if rows implement ColumnScanTyper interface.
+Both sqlscan and pgxscan implement it.
+
+To get the same shape as nested structs have, use WithNestedMaps option.
+Columns joined with the column separator are put into nested maps then,
+e.g. "user.id" and "user.email" columns end up as {"user": {"id": ..., "email": ...}}.
+
+Scanning into columns
+
+ScanAll allocates a destination element per row. For column-major processing, e.g. feeding charts or numeric code,
+ScanColumns appends values of each column to a separate slice instead.
+The destination is a struct with slice fields, matched to columns with the same rules as described above,
+or a map with slice values:
+
+	type UserColumns struct {
+		IDs    []string `db:"user_id"`
+		Emails []string `db:"email"`
+	}
+
+	var columns UserColumns
+	dbscan.ScanColumns(&columns, rows)
+
+Scanning into dynamic structs
+
+When the columns aren't known at compile time, ScanAllDynamic builds a struct type from the rows columns
+and scans rows into values of that type, see ScanAllDynamic for details:
+
+	values, structType, err := dbscan.ScanAllDynamic(rows)
+	// values contains a pointer to a struct of structType per row.
+
 Scanning into other types
 
 If the destination isn't a struct nor a map, dbscan handles it as a single column scan,
@@ -206,15 +243,74 @@ ScanAll and ScanOne functions take care of rows processing,
 they iterate rows to the end and close them after that.
 Client code doesn't need to bother with that. It just passes rows to dbscan.
 
+Multiple result sets
+
+If rows contain multiple result sets, ScanAllSets scans each of them into the corresponding destination.
+Wrap a destination with One to require exactly one row in the result set, like ScanOne does,
+or pass nil to skip the result set:
+
+	var users []*User
+	var count int
+	dbscan.ScanAllSets([]interface{}{&users, nil, dbscan.One(&count)}, rows)
+
+ScanAllSets leaves remaining destinations untouched if there are fewer result sets,
+use ScanAllSetsStrict to get an error when the number of result sets doesn't match the number of destinations.
+
+Generated scanning code
+
+Mapping columns to struct fields via reflection has its cost on every row.
+If a struct implements ColumnPointerer interface by a pointer, dbscan uses it to get pointers to the struct fields instead,
+as long as the method is declared on the struct itself and the API uses the default field name mapper
+and the same struct tag key and column separator as the generated code.
+The scanygen command generates implementations of this interface
+that follow the same mapping rules as described above:
+
+	//go:generate go run github.com/georgysavva/scany/v2/cmd/scanygen -type User
+
+See https://pkg.go.dev/github.com/georgysavva/scany/v2/cmd/scanygen for details.
+
 Manual rows iteration
 
 It's possible to manually control rows iteration but still use all scanning features of dbscan,
 see RowScanner for details.
 
+Extracting values from structs
+
+dbscan can work in the opposite direction as well.
+Values function returns column names and the corresponding values of a struct,
+it follows the same mapping rules as scanning does:
+
+	user := &User{ID: "bob", FirstName: "Bob", Email: "bob@example.com"}
+	columns, values, err := dbscan.Values(user)
+	// columns: "user_id", "first_name", "email".
+	// values: "bob", "Bob", "bob@example.com".
+
+InsertQuery and UpdateQuery functions use Values to render INSERT and UPDATE statements
+with placeholders for the given database, see InsertQuery and UpdateQuery for details:
+
+	query, args, err := dbscan.UpdateQuery("users", user, dbscan.DollarPlaceholder, "user_id")
+	// query: "UPDATE users SET first_name = $1, email = $2 WHERE user_id = $3".
+	// args: "Bob", "bob@example.com", "bob".
+
+Exporting rows
+
+WriteCSV and WriteNDJSON stream rows to an io.Writer in the CSV and newline delimited JSON formats,
+without buffering the full result. Rows are scanned the same way as they would be scanned into a map,
+and NULLs, times and byte slices are formatted according to WriteOption options:
+
+	dbscan.WriteCSV(os.Stdout, rows, dbscan.WithNullValue("NULL"), dbscan.WithTimeLayout("2006-01-02"))
+
 Overriding default settings
 
 dbscan has API type, which you can use to set custom settings, see API for details.
 
+Testing
+
+Package dbscantest provides FakeRows, an in-memory implementation of Rows
+that scans values with database/sql conversion rules.
+It allows unit testing code that uses dbscan without a database,
+see https://pkg.go.dev/github.com/georgysavva/scany/v2/dbscan/dbscantest for details.
+
 Implementing Rows interface
 
 dbscan can be used with any database library with a concept of rows and can implement dbscan Rows interface.
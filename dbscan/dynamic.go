@@ -0,0 +1,196 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// ScanAllDynamic is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllDynamic for details.
+func ScanAllDynamic(rows Rows) ([]interface{}, reflect.Type, error) {
+	return DefaultAPI.ScanAllDynamic(rows)
+}
+
+// ScanAllDynamic scans all rows into values of a struct type that is built at runtime from the rows columns.
+// It's useful for ad-hoc queries, when the columns aren't known at compile time,
+// but the values should be typed, e.g. to be serialized to JSON.
+// Each returned element is a pointer to a struct of the returned type.
+//
+// Every column gets an exported field with the name derived by inverting the field name mapper of the API:
+// the column name converted to camel case, e.g. "user_id" becomes UserId, or the column name as is,
+// e.g. "USER_ID" with a strings.ToUpper mapper, whichever the mapper maps back to the column name.
+// If neither does, e.g. for "user.email", the camel case form is used, so the field name is always valid.
+// Fields are bound to the columns by position, so any column name works, including expressions like "coalesce(a,b)".
+// The field has the struct tag with the exact column name and the json tag with the same value,
+// so JSON keys match the columns. Tags can't contain a column name with a comma,
+// such a field has no tags and its JSON key is the field name.
+// A column named "-" only gets the json tag "-,", which encoding/json reads as the "-" key.
+// If rows implement the ColumnScanTyper interface, a field type is a pointer to the type of the column,
+// so NULL values are represented as nil. Otherwise, or if the column type is unknown, the field type is interface{}.
+//
+// Struct types are cached by the columns and their types, so queries with the same columns reuse the same type.
+// The cache holds up to 1000 types per API, types of further column sets are built on every call.
+// Keep in mind that reflect.StructOf itself keeps every distinct struct type it built for the life of the program,
+// so ScanAllDynamic isn't meant for queries with an unbounded number of distinct column names.
+// ScanAllDynamic closes the rows the same way as ScanAll does it.
+func (api *API) ScanAllDynamic(rows Rows) ([]interface{}, reflect.Type, error) {
+	defer rows.Close() //nolint: errcheck
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	var columnTypes []reflect.Type
+	if typer, ok := rows.(ColumnScanTyper); ok {
+		columnTypes, err = typer.ColumnScanTypes()
+		if err != nil {
+			return nil, nil, fmt.Errorf("scany: get rows column types: %w", err)
+		}
+		if len(columnTypes) != len(columns) {
+			return nil, nil, fmt.Errorf("scany: number of column types must equal number of columns, got %d and %d",
+				len(columnTypes), len(columns))
+		}
+	}
+	structType := api.getDynamicStructType(columns, columnTypes)
+	// Field i holds column i, so values are scanned by position rather than matched by the struct tags,
+	// which can't express every column name, e.g. "coalesce(a,b)".
+	result := make([]interface{}, 0)
+	pointers := make([]interface{}, len(columns))
+	for rows.Next() {
+		v := reflect.New(structType)
+		elem := v.Elem()
+		for i := range pointers {
+			pointers[i] = elem.Field(i).Addr().Interface()
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, fmt.Errorf("scany: scan row into dynamic struct: %w", err)
+		}
+		result = append(result, v.Interface())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("scany: rows final error: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, nil, fmt.Errorf("scany: close rows after processing: %w", err)
+	}
+	return result, structType, nil
+}
+
+func (api *API) getDynamicStructType(columns []string, columnTypes []reflect.Type) reflect.Type {
+	var key strings.Builder
+	for i, column := range columns {
+		key.WriteString(strconv.Quote(column))
+		if columnTypes != nil && columnTypes[i] != nil {
+			key.WriteString(columnTypes[i].PkgPath())
+			key.WriteString(columnTypes[i].String())
+		}
+		key.WriteByte(';')
+	}
+	resultIface, ok := api.dynamicTypesCache.Load(key.String())
+	if ok {
+		return resultIface.(reflect.Type)
+	}
+
+	result := api.buildDynamicStructType(columns, columnTypes)
+	if atomic.AddInt32(&api.dynamicTypesCount, 1) > maxDynamicTypes {
+		atomic.AddInt32(&api.dynamicTypesCount, -1)
+		return result
+	}
+	resultIface, loaded := api.dynamicTypesCache.LoadOrStore(key.String(), result)
+	if loaded {
+		atomic.AddInt32(&api.dynamicTypesCount, -1)
+	}
+	return resultIface.(reflect.Type)
+}
+
+// maxDynamicTypes limits the number of struct types cached by ScanAllDynamic.
+const maxDynamicTypes = 1000
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+func (api *API) buildDynamicStructType(columns []string, columnTypes []reflect.Type) reflect.Type {
+	fields := make([]reflect.StructField, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, column := range columns {
+		name := api.dynamicFieldName(column)
+		// Different columns might end up with the same field name, e.g. "user_id" and "UserID".
+		base := name
+		for n := 2; seen[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+		seen[name] = true
+		fieldType := interfaceType
+		if columnTypes != nil && columnTypes[i] != nil {
+			fieldType = reflect.PtrTo(columnTypes[i])
+		}
+		fields[i] = reflect.StructField{Name: name, Type: fieldType, Tag: api.dynamicFieldTag(column)}
+	}
+	return reflect.StructOf(fields)
+}
+
+// dynamicFieldTag returns the struct tag and the json tag with the column name, as far as the tags can express it:
+// tag parsers treat a comma as the start of options and "-" as an ignored field.
+func (api *API) dynamicFieldTag(column string) reflect.StructTag {
+	switch {
+	case strings.Contains(column, ","):
+		return ""
+	case column == "-":
+		// encoding/json reads "-," as the "-" key, there is no such escape for the struct tag.
+		return `json:"-,"`
+	}
+	tag := fmt.Sprintf("%s:%s", api.structTagKey, strconv.Quote(column))
+	if api.structTagKey != "json" {
+		tag += fmt.Sprintf(" json:%s", strconv.Quote(column))
+	}
+	return reflect.StructTag(tag)
+}
+
+// dynamicFieldName inverts the field name mapper of the API for the column,
+// see ScanAllDynamic for details.
+func (api *API) dynamicFieldName(column string) string {
+	camelCase := camelCaseFieldName(column)
+	if api.fieldMapperFn(camelCase) == column {
+		return camelCase
+	}
+	if isExportedIdentifier(column) && api.fieldMapperFn(column) == column {
+		return column
+	}
+	return camelCase
+}
+
+// camelCaseFieldName converts a column name to an exported Go identifier in camel case.
+func camelCaseFieldName(column string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range column {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	name := sb.String()
+	if name == "" || !unicode.IsUpper([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func isExportedIdentifier(s string) bool {
+	for i, r := range s {
+		if i == 0 && !unicode.IsUpper(r) {
+			return false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,102 @@
+package dbscan_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+func TestScanAllDynamic(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"user_id", "user.email", "UserID", "2fa"},
+		Rows: [][]interface{}{
+			{makeStrPtr("1"), makeStrPtr("foo@example.com"), makeStrPtr("2"), true},
+			{makeStrPtr("3"), nil, makeStrPtr("4"), false},
+		},
+		ColumnTypes: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(""), reflect.TypeOf(""), nil},
+	})
+
+	got, gotType, err := testAPI.ScanAllDynamic(rows)
+	require.NoError(t, err)
+
+	expectedFields := []string{
+		`UserId *string db:"user_id" json:"user_id"`,
+		`UserEmail *string db:"user.email" json:"user.email"`,
+		`UserID *string db:"UserID" json:"UserID"`,
+		`X2fa interface {} db:"2fa" json:"2fa"`,
+	}
+	gotFields := make([]string, gotType.NumField())
+	for i := range gotFields {
+		f := gotType.Field(i)
+		gotFields[i] = f.Name + " " + f.Type.String() + " " + string(f.Tag)
+	}
+	assert.Equal(t, expectedFields, gotFields)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"user_id": "1", "user.email": "foo@example.com", "UserID": "2", "2fa": true},
+		{"user_id": "3", "user.email": null, "UserID": "4", "2fa": false}
+	]`, string(gotJSON))
+}
+
+func TestScanAllDynamic_sameColumns_reusesType(t *testing.T) {
+	t.Parallel()
+	newRows := func() *dbscantest.FakeRows {
+		return dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+			Columns: []string{"foo", "foo_"},
+			Rows:    [][]interface{}{{"foo val", "foo val 2"}},
+		})
+	}
+
+	_, firstType, err := testAPI.ScanAllDynamic(newRows())
+	require.NoError(t, err)
+	_, secondType, err := testAPI.ScanAllDynamic(newRows())
+	require.NoError(t, err)
+
+	assert.Equal(t, firstType, secondType)
+	assert.Equal(t, "Foo2", firstType.Field(1).Name)
+}
+
+func TestScanAllDynamic_customFieldNameMapper(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithFieldNameMapper(strings.ToUpper))
+	require.NoError(t, err)
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"USER_ID", "EMAIL", "user_name"},
+		Rows:    [][]interface{}{{"1", "foo@example.com", "foo"}},
+	})
+
+	_, gotType, err := api.ScanAllDynamic(rows)
+	require.NoError(t, err)
+
+	gotNames := make([]string, gotType.NumField())
+	for i := range gotNames {
+		gotNames[i] = gotType.Field(i).Name
+	}
+	assert.Equal(t, []string{"USER_ID", "EMAIL", "UserName"}, gotNames)
+}
+
+func TestScanAllDynamic_columnsTagsCantExpress(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"coalesce(a,b)", "-", "id"},
+		Rows:    [][]interface{}{{"a val", "dash val", int64(1)}},
+	})
+
+	got, gotType, err := testAPI.ScanAllDynamic(rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, reflect.StructTag(""), gotType.Field(0).Tag)
+	assert.Equal(t, reflect.StructTag(`json:"-,"`), gotType.Field(1).Tag)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"CoalesceAB": "a val", "-": "dash val", "id": 1}]`, string(gotJSON))
+}
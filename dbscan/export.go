@@ -0,0 +1,281 @@
+package dbscan
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// BytesEncoding defines how WriteCSV and WriteNDJSON format []byte values.
+type BytesEncoding int
+
+const (
+	// BytesAsString writes []byte values as is, it suits drivers that return text columns as []byte.
+	BytesAsString BytesEncoding = iota
+	// BytesAsBase64 writes []byte values encoded with the standard base64 encoding.
+	BytesAsBase64
+	// BytesAsHex writes []byte values encoded as hex.
+	BytesAsHex
+)
+
+// WriteOption is a function type that changes how WriteCSV and WriteNDJSON format values.
+type WriteOption func(wo *writeOptions)
+
+type writeOptions struct {
+	nullValue     string
+	timeLayout    string
+	bytesEncoding BytesEncoding
+	csvComma      rune
+}
+
+// WithNullValue sets the text that WriteCSV writes for NULL values.
+// The default is an empty string. WriteNDJSON always writes JSON null.
+func WithNullValue(nullValue string) WriteOption {
+	return func(wo *writeOptions) {
+		wo.nullValue = nullValue
+	}
+}
+
+// WithTimeLayout sets the layout that time.Time values are formatted with.
+// The default layout is time.RFC3339Nano.
+func WithTimeLayout(layout string) WriteOption {
+	return func(wo *writeOptions) {
+		wo.timeLayout = layout
+	}
+}
+
+// WithBytesEncoding sets how []byte values are formatted.
+// The default is BytesAsString for WriteCSV and BytesAsBase64 for WriteNDJSON,
+// the latter matches how encoding/json encodes []byte and keeps binary data intact.
+// With BytesAsString WriteNDJSON still falls back to base64 for values that aren't valid UTF-8.
+func WithBytesEncoding(encoding BytesEncoding) WriteOption {
+	return func(wo *writeOptions) {
+		wo.bytesEncoding = encoding
+	}
+}
+
+// WithCSVComma sets the field delimiter that WriteCSV uses.
+// The default delimiter is ',' character.
+func WithCSVComma(comma rune) WriteOption {
+	return func(wo *writeOptions) {
+		wo.csvComma = comma
+	}
+}
+
+func newWriteOptions(bytesEncoding BytesEncoding, opts []WriteOption) *writeOptions {
+	wo := &writeOptions{
+		timeLayout:    time.RFC3339Nano,
+		bytesEncoding: bytesEncoding,
+		csvComma:      ',',
+	}
+	for _, o := range opts {
+		o(wo)
+	}
+	return wo
+}
+
+// WriteCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.WriteCSV for details.
+func WriteCSV(w io.Writer, rows Rows, opts ...WriteOption) error {
+	return DefaultAPI.WriteCSV(w, rows, opts...)
+}
+
+// WriteNDJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.WriteNDJSON for details.
+func WriteNDJSON(w io.Writer, rows Rows, opts ...WriteOption) error {
+	return DefaultAPI.WriteNDJSON(w, rows, opts...)
+}
+
+// WriteCSV iterates all rows to the end and writes them to w in the CSV format,
+// the first record is a header with the rows columns. After iterating it closes the rows,
+// and propagates any errors that could pop up.
+// Rows are streamed one by one, they are never buffered all at once.
+// Each row is scanned the same way as it would be scanned into a map[string]interface{},
+// so the API settings for maps apply, e.g. WithColumnTypedMaps or WithMapBytesToString.
+// Values are formatted according to the options, see WriteOption for details.
+func (api *API) WriteCSV(w io.Writer, rows Rows, opts ...WriteOption) error {
+	wo := newWriteOptions(BytesAsString, opts)
+	cw := csv.NewWriter(w)
+	cw.Comma = wo.csvComma
+	var record []string
+	err := api.writeRows(rows, func(columns []string) error {
+		record = make([]string, len(columns))
+		return cw.Write(columns)
+	}, func(values []interface{}) error {
+		for i, v := range values {
+			record[i] = wo.formatText(v)
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("scany: write csv: %w", err)
+	}
+	return nil
+}
+
+// WriteNDJSON iterates all rows to the end and writes them to w as newline delimited JSON,
+// one JSON object per row with keys in the order of the rows columns. After iterating it closes the rows,
+// and propagates any errors that could pop up.
+// Rows are streamed one by one, they are never buffered all at once.
+// Each row is scanned the same way as it would be scanned into a map[string]interface{},
+// so the API settings for maps apply, e.g. WithColumnTypedMaps or WithMapBytesToString.
+// NULL values are written as JSON null, times and byte slices are formatted according to the options,
+// other values are encoded with encoding/json.
+func (api *API) WriteNDJSON(w io.Writer, rows Rows, opts ...WriteOption) error {
+	wo := newWriteOptions(BytesAsBase64, opts)
+	bw := bufio.NewWriter(w)
+	var columnNames []string
+	var keys [][]byte
+	var buf bytes.Buffer
+	err := api.writeRows(rows, func(columns []string) error {
+		columnNames = columns
+		keys = make([][]byte, len(columns))
+		for i, column := range columns {
+			key, err := json.Marshal(column)
+			if err != nil {
+				return err
+			}
+			keys[i] = key
+		}
+		return nil
+	}, func(values []interface{}) error {
+		buf.Reset()
+		buf.WriteByte('{')
+		for i, v := range values {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(keys[i])
+			buf.WriteByte(':')
+			if err := wo.writeJSON(&buf, v); err != nil {
+				return fmt.Errorf("column '%s': %w", columnNames[i], err)
+			}
+		}
+		buf.WriteString("}\n")
+		_, err := bw.Write(buf.Bytes())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("scany: write ndjson: %w", err)
+	}
+	return nil
+}
+
+func (api *API) writeRows(
+	rows Rows, writeHeader func(columns []string) error, writeValues func(values []interface{}) error,
+) error {
+	defer rows.Close() //nolint: errcheck
+	columns, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("scany: get rows columns: %w", err)
+	}
+	if err := writeHeader(columns); err != nil {
+		return fmt.Errorf("scany: write header: %w", err)
+	}
+	rs := api.NewRowScanner(rows)
+	// Values are looked up by the column names, so they must stay flat.
+	rs.flatMaps = true
+	var row map[string]interface{}
+	values := make([]interface{}, len(columns))
+	for rows.Next() {
+		if err := rs.Scan(&row); err != nil {
+			return fmt.Errorf("scanning: %w", err)
+		}
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		if err := writeValues(values); err != nil {
+			return fmt.Errorf("scany: write row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("scany: rows final error: %w", err)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("scany: close rows after processing: %w", err)
+	}
+	return nil
+}
+
+func (wo *writeOptions) formatText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return wo.nullValue
+	case string:
+		return v
+	case []byte:
+		return wo.formatBytes(v)
+	case time.Time:
+		return v.Format(wo.timeLayout)
+	case bool:
+		return strconv.FormatBool(v)
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		if _, ok := value.(driver.Valuer); ok {
+			return fmt.Sprint(value)
+		}
+		return wo.formatText(value)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (wo *writeOptions) formatBytes(b []byte) string {
+	switch wo.bytesEncoding {
+	case BytesAsBase64:
+		return base64.StdEncoding.EncodeToString(b)
+	case BytesAsHex:
+		return hex.EncodeToString(b)
+	default:
+		return string(b)
+	}
+}
+
+func (wo *writeOptions) writeJSON(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+		return nil
+	case []byte:
+		if wo.bytesEncoding == BytesAsString && !utf8.Valid(v) {
+			return wo.writeJSONString(buf, base64.StdEncoding.EncodeToString(v))
+		}
+		return wo.writeJSONString(buf, wo.formatBytes(v))
+	case time.Time:
+		return wo.writeJSONString(buf, v.Format(wo.timeLayout))
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
+
+func (wo *writeOptions) writeJSONString(buf *bytes.Buffer, s string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
@@ -0,0 +1,133 @@
+package dbscan_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+)
+
+func newExportRows() *dbscantest.FakeRows {
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	return dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"id", "name", "data", "created_at", "price"},
+		Rows: [][]interface{}{
+			{int64(1), "foo, \"bar\"", []byte("raw"), createdAt, pgtype.Numeric{Int: bigInt(1050), Exp: -2, Valid: true}},
+			{int64(2), nil, nil, nil, pgtype.Numeric{}},
+		},
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		opts     []dbscan.WriteOption
+		expected string
+	}{
+		{
+			name: "default options",
+			expected: "id,name,data,created_at,price\n" +
+				"1,\"foo, \"\"bar\"\"\",raw,2020-01-02T03:04:05Z,10.50\n" +
+				"2,,,,\n",
+		},
+		{
+			name: "custom options",
+			opts: []dbscan.WriteOption{
+				dbscan.WithNullValue("NULL"),
+				dbscan.WithTimeLayout("2006-01-02"),
+				dbscan.WithBytesEncoding(dbscan.BytesAsHex),
+				dbscan.WithCSVComma(';'),
+			},
+			expected: "id;name;data;created_at;price\n" +
+				"1;\"foo, \"\"bar\"\"\";726177;2020-01-02;10.50\n" +
+				"2;NULL;NULL;NULL;NULL\n",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := newExportRows()
+			var sb strings.Builder
+
+			err := testAPI.WriteCSV(&sb, rows, tc.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, sb.String())
+			assert.True(t, rows.Closed())
+		})
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		opts     []dbscan.WriteOption
+		expected string
+	}{
+		{
+			name: "default options",
+			expected: `{"id":1,"name":"foo, \"bar\"","data":"cmF3","created_at":"2020-01-02T03:04:05Z","price":10.50}` + "\n" +
+				`{"id":2,"name":null,"data":null,"created_at":null,"price":null}` + "\n",
+		},
+		{
+			name: "bytes as string",
+			opts: []dbscan.WriteOption{dbscan.WithBytesEncoding(dbscan.BytesAsString)},
+			expected: `{"id":1,"name":"foo, \"bar\"","data":"raw","created_at":"2020-01-02T03:04:05Z","price":10.50}` + "\n" +
+				`{"id":2,"name":null,"data":null,"created_at":null,"price":null}` + "\n",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			rows := newExportRows()
+			var sb strings.Builder
+
+			err := testAPI.WriteNDJSON(&sb, rows, tc.opts...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, sb.String())
+			assert.True(t, rows.Closed())
+		})
+	}
+}
+
+func TestWriteNDJSON_bytesAsStringInvalidUTF8_fallsBackToBase64(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"data"},
+		Rows:    [][]interface{}{{[]byte{0xff, 0x00, 0xfe}}},
+	})
+	var sb strings.Builder
+
+	err := testAPI.WriteNDJSON(&sb, rows, dbscan.WithBytesEncoding(dbscan.BytesAsString))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"data":"/wD+"}`+"\n", sb.String())
+}
+
+func TestWriteNDJSON_valueFailsToEncode_returnsErrWithColumnName(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"ch"},
+		Rows:    [][]interface{}{{make(chan int)}},
+	})
+	var sb strings.Builder
+
+	err := testAPI.WriteNDJSON(&sb, rows)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "column 'ch': ")
+}
+
+func bigInt(v int64) *big.Int { return big.NewInt(v) }
@@ -0,0 +1,170 @@
+// Package bench_test contains benchmarks of dbscan that don't need a database,
+// unlike the dbscan tests, so they can be run on their own:
+//
+//	go test -run '^$' -bench . ./dbscan/internal/bench
+package bench_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func newBenchAPI(b *testing.B) *dbscan.API {
+	b.Helper()
+	api, err := dbscan.NewAPI(dbscan.WithScannableTypes((*sql.Scanner)(nil)))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return api
+}
+
+// benchRows is an infinite dbscan.Rows that returns the same row of strings,
+// so benchmarks measure the scanning overhead and not the database.
+type benchRows struct {
+	columns []string
+}
+
+func (br *benchRows) Close() error               { return nil }
+func (br *benchRows) Err() error                 { return nil }
+func (br *benchRows) Next() bool                 { return true }
+func (br *benchRows) Columns() ([]string, error) { return br.columns, nil }
+func (br *benchRows) NextResultSet() bool        { return false }
+
+func (br *benchRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		*d.(*string) = br.columns[i]
+	}
+	return nil
+}
+
+type wideModel struct {
+	Field0, Field1, Field2, Field3, Field4, Field5, Field6, Field7, Field8, Field9           string
+	Field10, Field11, Field12, Field13, Field14, Field15, Field16, Field17, Field18, Field19 string
+	Field20, Field21, Field22, Field23, Field24, Field25, Field26, Field27, Field28, Field29 string
+}
+
+type deepModel struct {
+	Level1 *deepLevel1
+	Foo    string
+}
+
+type deepLevel1 struct {
+	Level2 *deepLevel2
+	Foo    string
+}
+
+type deepLevel2 struct {
+	Level3 *deepLevel3
+	Foo    string
+}
+
+type deepLevel3 struct {
+	Foo string
+	Bar string
+}
+
+func benchmarkScan(b *testing.B, dst interface{}, columns []string) {
+	b.Helper()
+	rows := &benchRows{columns: columns}
+	rs := newBenchAPI(b).NewRowScanner(rows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows.Next()
+		if err := rs.Scan(dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRowScanner_Scan_wideStruct(b *testing.B) {
+	columns := make([]string, 30)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("field%d", i)
+	}
+	benchmarkScan(b, &wideModel{}, columns)
+}
+
+func BenchmarkRowScanner_Scan_deepNesting(b *testing.B) {
+	columns := []string{
+		"foo", "level1.foo", "level1.level2.foo", "level1.level2.level3.foo", "level1.level2.level3.bar",
+	}
+	// Reset the destination on every row, so nested structs have to be initialized each time.
+	rows := &benchRows{columns: columns}
+	rs := newBenchAPI(b).NewRowScanner(rows)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var dst deepModel
+		rows.Next()
+		if err := rs.Scan(&dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanAll_wideStruct(b *testing.B) {
+	columns := make([]string, 30)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("field%d", i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows := &limitedRows{benchRows: benchRows{columns: columns}, left: 100}
+		var dst []*wideModel
+		if err := dbscan.ScanAll(&dst, rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type limitedRows struct {
+	benchRows
+	left int
+}
+
+func (lr *limitedRows) Next() bool {
+	lr.left--
+	return lr.left >= 0
+}
+
+func BenchmarkRowScanner_Scan_map(b *testing.B) {
+	columns := make([]string, 10)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("field%d", i)
+	}
+	rows := &benchRows{columns: columns}
+	rs := newBenchAPI(b).NewRowScanner(rows)
+	dst := make(map[string]string, len(columns))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows.Next()
+		if err := rs.Scan(&dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkScanOne_wideStruct measures the per query overhead of scanning a single row,
+// e.g. compiling the struct plan for the columns.
+func BenchmarkScanOne_wideStruct(b *testing.B) {
+	columns := make([]string, 30)
+	for i := range columns {
+		columns[i] = fmt.Sprintf("field%d", i)
+	}
+	api := newBenchAPI(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows := &limitedRows{benchRows: benchRows{columns: columns}, left: 1}
+		var dst wideModel
+		if err := api.ScanOne(&dst, rows); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -25,7 +25,10 @@ func DoTestRowScannerStartCalledExactlyOnce(t *testing.T, api *API, queryRows qu
 	mockStart.On("Execute", rs, mock.AnythingOfType("reflect.Value")).Return(nil).Run(func(args mock.Arguments) {
 		rs := args.Get(0).(*RowScanner)
 		rs.columns = []string{"foo", "bar"}
-		rs.columnToFieldIndex = map[string][]int{"foo": {0}, "bar": {1}}
+		rs.structPlan = []columnPlan{
+			{fieldIndex: []int{0}, initializeNested: []bool{false}},
+			{fieldIndex: []int{1}, initializeNested: []bool{false}},
+		}
 		rs.scanFn = rs.scanStruct
 	})
 
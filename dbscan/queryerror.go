@@ -0,0 +1,67 @@
+package dbscan
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// QueryError wraps an error returned by the high-level functions of sqlscan and pgxscan, such as Select and Get,
+// and describes the query that caused it. Use errors.As to access it.
+// It's only returned if the API is configured with the WithQueryInErrors option of the corresponding package.
+type QueryError struct {
+	// Query is the query text, truncated to the configured length.
+	Query string
+	// Args are the query arguments after redaction, see RedactFunc.
+	Args []interface{}
+	Err  error
+}
+
+// Error implements the error interface.
+func (qe *QueryError) Error() string {
+	return fmt.Sprintf("%v; query: %q, args: %v", qe.Err, qe.Query, qe.Args)
+}
+
+// Unwrap returns the underlying error.
+func (qe *QueryError) Unwrap() error {
+	return qe.Err
+}
+
+// RedactFunc returns a value that replaces the query argument with the given index in QueryError,
+// e.g. a masked version of it.
+type RedactFunc func(index int, arg interface{}) interface{}
+
+// RedactedArg is the value that RedactAll puts in place of every argument.
+const RedactedArg = "<redacted>"
+
+// RedactAll is a RedactFunc that replaces every argument with RedactedArg,
+// so QueryError only reveals the number of arguments.
+func RedactAll(int, interface{}) interface{} {
+	return RedactedArg
+}
+
+// KeepArgs is a RedactFunc that keeps arguments as is.
+// Use it only if arguments don't contain secrets or personal data.
+func KeepArgs(_ int, arg interface{}) interface{} {
+	return arg
+}
+
+// NewQueryError returns a new QueryError for err, that is caused by the query with the given args.
+// The query is truncated to maxQueryLen runes, unless maxQueryLen is zero or less.
+// Arguments are replaced with the values returned by redact, RedactAll is used if redact is nil.
+// It returns nil if err is nil.
+func NewQueryError(err error, query string, args []interface{}, maxQueryLen int, redact RedactFunc) error {
+	if err == nil {
+		return nil
+	}
+	if redact == nil {
+		redact = RedactAll
+	}
+	if maxQueryLen > 0 && utf8.RuneCountInString(query) > maxQueryLen {
+		query = string([]rune(query)[:maxQueryLen]) + "..."
+	}
+	redacted := make([]interface{}, len(args))
+	for i, arg := range args {
+		redacted[i] = redact(i, arg)
+	}
+	return &QueryError{Query: query, Args: redacted, Err: err}
+}
@@ -0,0 +1,91 @@
+package dbscan_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestNewQueryError(t *testing.T) {
+	t.Parallel()
+	redactSecond := func(index int, arg interface{}) interface{} {
+		if index == 1 {
+			return "***"
+		}
+		return arg
+	}
+	cases := []struct {
+		name        string
+		query       string
+		args        []interface{}
+		maxQueryLen int
+		redact      dbscan.RedactFunc
+		expected    *dbscan.QueryError
+	}{
+		{
+			name:     "nil redact masks all args",
+			query:    "SELECT * FROM users WHERE email = $1",
+			args:     []interface{}{"bob@example.com"},
+			expected: &dbscan.QueryError{Query: "SELECT * FROM users WHERE email = $1", Args: []interface{}{"<redacted>"}},
+		},
+		{
+			name:     "keep args",
+			query:    "SELECT * FROM users WHERE id = $1",
+			args:     []interface{}{1},
+			redact:   dbscan.KeepArgs,
+			expected: &dbscan.QueryError{Query: "SELECT * FROM users WHERE id = $1", Args: []interface{}{1}},
+		},
+		{
+			name:     "custom redact",
+			query:    "SELECT * FROM users WHERE id = $1 AND password = $2",
+			args:     []interface{}{1, "secret"},
+			redact:   redactSecond,
+			expected: &dbscan.QueryError{Query: "SELECT * FROM users WHERE id = $1 AND password = $2", Args: []interface{}{1, "***"}},
+		},
+		{
+			name:        "truncated query",
+			query:       "SELECT naïve FROM users",
+			maxQueryLen: 11,
+			expected:    &dbscan.QueryError{Query: "SELECT naïv...", Args: []interface{}{}},
+		},
+		{
+			name:        "short query isn't truncated",
+			query:       "SELECT 1",
+			maxQueryLen: 8,
+			expected:    &dbscan.QueryError{Query: "SELECT 1", Args: []interface{}{}},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cause := errors.New("some error")
+
+			err := dbscan.NewQueryError(cause, tc.query, tc.args, tc.maxQueryLen, tc.redact)
+
+			var queryErr *dbscan.QueryError
+			require.ErrorAs(t, err, &queryErr)
+			tc.expected.Err = cause
+			assert.Equal(t, tc.expected, queryErr)
+			assert.ErrorIs(t, err, cause)
+		})
+	}
+}
+
+func TestNewQueryError_nilErr_returnsNil(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, dbscan.NewQueryError(nil, "SELECT 1", nil, 0, nil))
+}
+
+func TestQueryError_Error(t *testing.T) {
+	t.Parallel()
+	err := fmt.Errorf("scanning all: %w", dbscan.NewQueryError(
+		errors.New("some error"), "SELECT * FROM users WHERE id = $1", []interface{}{1}, 0, nil,
+	))
+	assert.EqualError(t, err, `scanning all: some error; query: "SELECT * FROM users WHERE id = $1", args: [<redacted>]`)
+}
@@ -0,0 +1,111 @@
+package dbscan
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy describes how the high-level functions of sqlscan and pgxscan, such as Select and Get,
+// retry queries that fail with a transient error, e.g. a serialization failure.
+// Use the WithRetryPolicy option of the corresponding package to set it.
+// A query is only retried if it failed before any row was read, so the destination never gets rows
+// from different attempts. Queries are sent again as is, retry only queries that are safe to repeat.
+// Queries made in a transaction aren't retried, since the transaction is aborted after an error.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// Backoff returns the delay after the given number of failed attempts.
+	// If it's nil, ExponentialBackoff(10*time.Millisecond, time.Second) is used.
+	Backoff BackoffFunc
+	// Retryable reports whether the query should be retried after the error.
+	// If it's nil, IsRetryable is used.
+	Retryable func(err error) bool
+}
+
+// BackoffFunc returns the delay before the next attempt after the given number of failed attempts.
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff returns a BackoffFunc that doubles the delay with each attempt starting from initial,
+// up to maxDelay. A random jitter of up to a half of the delay is subtracted,
+// so concurrent retries don't hit the database at the same time.
+func ExponentialBackoff(initial, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		delay := maxDelay
+		if attempt < 32 {
+			if d := initial << (attempt - 1); d > 0 && d < maxDelay {
+				delay = d
+			}
+		}
+		if delay <= 1 {
+			return delay
+		}
+		return delay - time.Duration(rand.Int63n(int64(delay/2)+1)) //nolint: gosec
+	}
+}
+
+// retryableSQLStates contains Postgres and CockroachDB error codes that are safe to retry:
+// serialization failure, deadlock and connection failures.
+var retryableSQLStates = map[string]bool{
+	"40001": true, // serialization_failure, also used by CockroachDB for transaction retry errors.
+	"40P01": true, // deadlock_detected.
+	"08000": true, // connection_exception.
+	"08003": true, // connection_does_not_exist.
+	"08006": true, // connection_failure.
+	"57P01": true, // admin_shutdown.
+}
+
+// IsRetryable is the default classifier of RetryPolicy. It reports whether the error is transient:
+// either it has a retryable Postgres or CockroachDB SQLSTATE code, such as 40001 (serialization failure),
+// or the connection was lost, e.g. driver.ErrBadConn or a connection reset.
+// The SQLSTATE code is taken from an error in the chain that has the SQLState() string method,
+// as *pgconn.PgError does.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var sqlStateErr interface{ SQLState() string }
+	if errors.As(err, &sqlStateErr) {
+		return retryableSQLStates[sqlStateErr.SQLState()]
+	}
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// Run calls fn until it succeeds or the policy gives up, and returns the last error.
+// fn reports whether any row was read, in which case its error isn't retried.
+// Run waits between attempts according to Backoff, it doesn't retry if ctx is done
+// or its deadline comes before the next attempt.
+func (rp *RetryPolicy) Run(ctx context.Context, fn func() (rowsRead bool, err error)) error {
+	backoff := rp.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(10*time.Millisecond, time.Second)
+	}
+	retryable := rp.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	for attempt := 1; ; attempt++ {
+		rowsRead, err := fn()
+		if err == nil || rowsRead || attempt >= rp.MaxAttempts || !retryable(err) {
+			return err
+		}
+		delay := backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
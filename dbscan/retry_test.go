@@ -0,0 +1,148 @@
+package dbscan_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}, expected: true},
+		{name: "wrapped deadlock", err: fmt.Errorf("scanning: %w", &pgconn.PgError{Code: "40P01"}), expected: true},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, expected: false},
+		{name: "bad connection", err: driver.ErrBadConn, expected: true},
+		{name: "connection reset", err: fmt.Errorf("read: %w", syscall.ECONNRESET), expected: true},
+		{name: "context canceled", err: fmt.Errorf("query: %w", context.Canceled), expected: false},
+		{name: "other error", err: errors.New("some error"), expected: false},
+		{name: "nil", err: nil, expected: false},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, dbscan.IsRetryable(tc.err))
+		})
+	}
+}
+
+func TestRetryPolicy_Run(t *testing.T) {
+	t.Parallel()
+	retryableErr := &pgconn.PgError{Code: "40001"}
+	noBackoff := func(int) time.Duration { return 0 }
+	cases := []struct {
+		name             string
+		policy           dbscan.RetryPolicy
+		results          []error
+		rowsRead         bool
+		expectedAttempts int
+		expectedErr      error
+	}{
+		{
+			name:             "succeeds after retries",
+			policy:           dbscan.RetryPolicy{MaxAttempts: 3, Backoff: noBackoff},
+			results:          []error{retryableErr, retryableErr, nil},
+			expectedAttempts: 3,
+		},
+		{
+			name:             "gives up after max attempts",
+			policy:           dbscan.RetryPolicy{MaxAttempts: 2, Backoff: noBackoff},
+			results:          []error{retryableErr, retryableErr, nil},
+			expectedAttempts: 2,
+			expectedErr:      retryableErr,
+		},
+		{
+			name:             "non retryable error",
+			policy:           dbscan.RetryPolicy{MaxAttempts: 3, Backoff: noBackoff},
+			results:          []error{errors.New("syntax error"), nil},
+			expectedAttempts: 1,
+			expectedErr:      errors.New("syntax error"),
+		},
+		{
+			name:             "rows read",
+			policy:           dbscan.RetryPolicy{MaxAttempts: 3, Backoff: noBackoff},
+			results:          []error{retryableErr, nil},
+			rowsRead:         true,
+			expectedAttempts: 1,
+			expectedErr:      retryableErr,
+		},
+		{
+			name: "custom classifier",
+			policy: dbscan.RetryPolicy{
+				MaxAttempts: 3,
+				Backoff:     noBackoff,
+				Retryable:   func(err error) bool { return err.Error() == "try again" },
+			},
+			results:          []error{errors.New("try again"), nil},
+			expectedAttempts: 2,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			attempts := 0
+			err := tc.policy.Run(context.Background(), func() (bool, error) {
+				err := tc.results[attempts]
+				attempts++
+				return tc.rowsRead, err
+			})
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestRetryPolicy_Run_deadlineBeforeBackoff_doesNotRetry(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	policy := dbscan.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     func(int) time.Duration { return time.Hour },
+	}
+	retryableErr := &pgconn.PgError{Code: "40001"}
+	attempts := 0
+
+	err := policy.Run(ctx, func() (bool, error) {
+		attempts++
+		return false, retryableErr
+	})
+
+	assert.Equal(t, retryableErr, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+	backoff := dbscan.ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	cases := []struct {
+		attempt  int
+		maxDelay time.Duration
+	}{
+		{attempt: 1, maxDelay: 10 * time.Millisecond},
+		{attempt: 2, maxDelay: 20 * time.Millisecond},
+		{attempt: 3, maxDelay: 40 * time.Millisecond},
+		{attempt: 4, maxDelay: 50 * time.Millisecond},
+		{attempt: 100, maxDelay: 50 * time.Millisecond},
+	}
+	for _, tc := range cases {
+		delay := backoff(tc.attempt)
+		assert.LessOrEqual(t, delay, tc.maxDelay, "attempt %d", tc.attempt)
+		assert.GreaterOrEqual(t, delay, tc.maxDelay/2, "attempt %d", tc.attempt)
+	}
+}
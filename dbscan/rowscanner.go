@@ -3,10 +3,43 @@ package dbscan
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
 )
 
 type startScannerFunc func(rs *RowScanner, dstValue reflect.Value) error
 
+// ColumnPointerer is implemented by structs that can provide pointers to their fields
+// for the given columns without reflection.
+// The scanygen command generates implementations of this interface, see
+// https://pkg.go.dev/github.com/georgysavva/scany/v2/cmd/scanygen for details.
+// If a struct destination implements ColumnPointerer by a pointer,
+// RowScanner uses it instead of the reflection-based mapping,
+// but only if ColumnPointers is declared on the destination type itself, rather than promoted from an embedded struct,
+// and the API maps field names with SnakeCaseMapper and uses the same struct tag key and column separator.
+// Otherwise it falls back to reflection.
+type ColumnPointerer interface {
+	// ColumnPointers fills pointers with pointers to the fields corresponding to columns.
+	// It must initialize nil nested structs on the way to a field,
+	// and leave nil for columns that don't have a corresponding field.
+	ColumnPointers(columns []string, pointers []interface{})
+	// ColumnMapping returns a nil pointer to the struct type that declares ColumnPointers, e.g. (*User)(nil),
+	// and the struct tag key and the column separator that ColumnPointers follows.
+	ColumnMapping() (owner interface{}, tagKey, separator string)
+}
+
+var columnPointererType = reflect.TypeOf((*ColumnPointerer)(nil)).Elem()
+
+// ColumnScanTyper is an optional interface that Rows can implement
+// to tell which Go type values of each column should be scanned into.
+// It's used when scanning into a map with interface{} values and WithColumnTypedMaps option is on.
+type ColumnScanTyper interface {
+	// ColumnScanTypes returns a type for every column in the same order as Columns does.
+	// A nil type means that the column value is scanned into interface{} as usual.
+	ColumnScanTypes() ([]reflect.Type, error)
+}
+
 //go:generate mockery --name startScannerFunc --filename mock_test.go --inpackage
 
 // RowScanner embraces Rows and exposes the Scan method
@@ -26,15 +59,21 @@ type startScannerFunc func(rs *RowScanner, dstValue reflect.Value) error
 //
 // ScanOne and ScanAll both use RowScanner type internally.
 type RowScanner struct {
-	api                *API
-	rows               Rows
-	columns            []string
-	columnToFieldIndex map[string][]int
-	mapElementType     reflect.Type
-	started            bool
-	scanFn             func(dstVal reflect.Value) error
-	start              startScannerFunc
-	scans              []any
+	api            *API
+	rows           Rows
+	columns        []string
+	structPlan     []columnPlan
+	mapElementType reflect.Type
+	mapKeys        []reflect.Value
+	mapValues      []reflect.Value
+	mapTypedValues []bool
+	mapPaths       [][]reflect.Value
+	mapNestedRoots []reflect.Value
+	flatMaps       bool
+	started        bool
+	scanFn         func(dstVal reflect.Value) error
+	start          startScannerFunc
+	scans          []any
 }
 
 // NewRowScanner is a package-level helper function that uses the DefaultAPI object.
@@ -96,9 +135,13 @@ func startScanner(rs *RowScanner, dstValue reflect.Value) error {
 		return nil
 	}
 
+	if dstKind == reflect.Struct && rs.api.canUseColumnPointers(dstType) {
+		rs.scanFn = rs.scanColumnPointerer
+		return nil
+	}
+
 	if dstKind == reflect.Struct {
-		rs.columnToFieldIndex = rs.api.getColumnToFieldIndexMap(dstType)
-		rs.scanFn = rs.scanStruct
+		rs.startStruct(dstType)
 		return nil
 	}
 
@@ -109,8 +152,9 @@ func startScanner(rs *RowScanner, dstValue reflect.Value) error {
 				dstType, dstType.Key(),
 			)
 		}
-		rs.mapElementType = dstType.Elem()
-		rs.scanFn = rs.scanMap
+		if err := rs.startMap(dstType); err != nil {
+			return fmt.Errorf("starting map: %w", err)
+		}
 		return nil
 	}
 
@@ -124,19 +168,116 @@ func startScanner(rs *RowScanner, dstValue reflect.Value) error {
 	)
 }
 
+// canUseColumnPointers reports whether the generated ColumnPointers method of the struct
+// maps columns exactly the same way as the API does it via reflection.
+func (api *API) canUseColumnPointers(structType reflect.Type) bool {
+	if !reflect.PtrTo(structType).Implements(columnPointererType) {
+		return false
+	}
+	if reflect.ValueOf(api.fieldMapperFn).Pointer() != reflect.ValueOf(SnakeCaseMapper).Pointer() {
+		return false
+	}
+	cp := reflect.New(structType).Interface().(ColumnPointerer)
+	owner, tagKey, separator := cp.ColumnMapping()
+	return reflect.TypeOf(owner) == reflect.PtrTo(structType) &&
+		tagKey == api.structTagKey &&
+		separator == api.columnSeparator
+}
+
 type noOpScanType struct{}
 
 func (*noOpScanType) Scan(value interface{}) error {
 	return nil
 }
 
+// columnPlan describes how to get a pointer to the struct field for a column.
+// Plans are compiled once per struct type and columns and cached in the API,
+// so scanning a row doesn't need any map lookups.
+type columnPlan struct {
+	// fieldIndex is nil if the column doesn't have a corresponding field.
+	fieldIndex []int
+	// initializeNested[i] is true if the field at fieldIndex[:i+1] is a pointer to a struct,
+	// that must be initialized in case it's nil.
+	initializeNested []bool
+}
+
+// structPlanKey identifies a cached struct plan.
+type structPlanKey struct {
+	structType reflect.Type
+	columns    string
+}
+
+// maxStructPlans limits the number of struct plans cached by the API.
+const maxStructPlans = 1000
+
+func (rs *RowScanner) startStruct(structType reflect.Type) {
+	rs.structPlan = rs.api.getStructPlan(structType, rs.columns)
+	rs.scanFn = rs.scanStruct
+}
+
+func (api *API) getStructPlan(structType reflect.Type, columns []string) []columnPlan {
+	// Every column is prefixed with its length, so different columns can't produce the same key.
+	var columnsKey []byte
+	for _, column := range columns {
+		columnsKey = strconv.AppendInt(columnsKey, int64(len(column)), 10)
+		columnsKey = append(columnsKey, ':')
+		columnsKey = append(columnsKey, column...)
+	}
+	key := structPlanKey{structType: structType, columns: string(columnsKey)}
+	resultIface, ok := api.structPlansCache.Load(key)
+	if ok {
+		return resultIface.([]columnPlan)
+	}
+
+	result := api.buildStructPlan(structType, columns)
+	if atomic.AddInt32(&api.structPlansCount, 1) > maxStructPlans {
+		atomic.AddInt32(&api.structPlansCount, -1)
+		return result
+	}
+	resultIface, loaded := api.structPlansCache.LoadOrStore(key, result)
+	if loaded {
+		atomic.AddInt32(&api.structPlansCount, -1)
+	}
+	return resultIface.([]columnPlan)
+}
+
+func (api *API) buildStructPlan(structType reflect.Type, columns []string) []columnPlan {
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	plan := make([]columnPlan, len(columns))
+	var total int
+	for _, column := range columns {
+		total += len(columnToFieldIndex[column])
+	}
+	// All columns share a single backing array for their initializeNested flags.
+	initializeNested := make([]bool, total)
+	for i, column := range columns {
+		fieldIndex, ok := columnToFieldIndex[column]
+		if !ok {
+			continue
+		}
+		plan[i] = columnPlan{fieldIndex: fieldIndex, initializeNested: initializeNested[:len(fieldIndex):len(fieldIndex)]}
+		initializeNested = initializeNested[len(fieldIndex):]
+		t := structType
+		for j, x := range fieldIndex {
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			t = t.Field(x).Type
+			// Struct may contain embedded structs by ptr that defaults to nil.
+			// In order to scan values into a nested field,
+			// we need to initialize all nil structs on its way.
+			plan[i].initializeNested[j] = t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+		}
+	}
+	return plan
+}
+
 func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 	if rs.scans == nil {
 		rs.scans = make([]interface{}, len(rs.columns))
 	}
-	for i, column := range rs.columns {
-		fieldIndex, ok := rs.columnToFieldIndex[column]
-		if !ok {
+	for i, plan := range rs.structPlan {
+		if plan.fieldIndex == nil {
 			if rs.api.allowUnknownColumns {
 				var tmp noOpScanType
 				rs.scans[i] = &tmp
@@ -144,15 +285,19 @@ func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 			}
 			return fmt.Errorf(
 				"scany: column: '%s': no corresponding field found, or it's unexported in %v",
-				column, structValue.Type(),
+				rs.columns[i], structValue.Type(),
 			)
 		}
-		// Struct may contain embedded structs by ptr that defaults to nil.
-		// In order to scan values into a nested field,
-		// we need to initialize all nil structs on its way.
-		initializeNested(structValue, fieldIndex)
-
-		fieldVal := structValue.FieldByIndex(fieldIndex)
+		fieldVal := structValue
+		for j, x := range plan.fieldIndex {
+			if j > 0 && fieldVal.Kind() == reflect.Ptr {
+				fieldVal = fieldVal.Elem()
+			}
+			fieldVal = fieldVal.Field(x)
+			if plan.initializeNested[j] && fieldVal.IsNil() {
+				fieldVal.Set(reflect.New(fieldVal.Type().Elem()))
+			}
+		}
 		rs.scans[i] = fieldVal.Addr().Interface()
 	}
 	if err := rs.rows.Scan(rs.scans...); err != nil {
@@ -161,19 +306,135 @@ func (rs *RowScanner) scanStruct(structValue reflect.Value) error {
 	return nil
 }
 
+func (rs *RowScanner) scanColumnPointerer(structValue reflect.Value) error {
+	if rs.scans == nil {
+		rs.scans = make([]interface{}, len(rs.columns))
+	}
+	cp := structValue.Addr().Interface().(ColumnPointerer)
+	for i := range rs.scans {
+		rs.scans[i] = nil
+	}
+	cp.ColumnPointers(rs.columns, rs.scans)
+	for i, column := range rs.columns {
+		if rs.scans[i] != nil {
+			continue
+		}
+		if rs.api.allowUnknownColumns {
+			var tmp noOpScanType
+			rs.scans[i] = &tmp
+			continue
+		}
+		return fmt.Errorf(
+			"scany: column: '%s': no corresponding field found, or it's unexported in %v",
+			column, structValue.Type(),
+		)
+	}
+	if err := rs.rows.Scan(rs.scans...); err != nil {
+		return fmt.Errorf("scany: scan row into struct fields: %w", err)
+	}
+	return nil
+}
+
+// startMap allocates buffers for map keys and values once,
+// so they are reused for every row.
+func (rs *RowScanner) startMap(mapType reflect.Type) error {
+	rs.mapElementType = mapType.Elem()
+	columnTypes, err := rs.columnScanTypes()
+	if err != nil {
+		return err
+	}
+	rs.mapKeys = make([]reflect.Value, len(rs.columns))
+	rs.mapValues = make([]reflect.Value, len(rs.columns))
+	rs.mapTypedValues = make([]bool, len(rs.columns))
+	rs.scans = make([]interface{}, len(rs.columns))
+	for i, column := range rs.columns {
+		rs.mapKeys[i] = reflect.ValueOf(column)
+		valueType := rs.mapElementType
+		if columnTypes != nil && columnTypes[i] != nil {
+			// Scan into a pointer, so NULL values are handled by the database library.
+			valueType = reflect.PtrTo(columnTypes[i])
+			rs.mapTypedValues[i] = true
+		}
+		valuePtr := reflect.New(valueType)
+		rs.scans[i] = valuePtr.Interface()
+		rs.mapValues[i] = valuePtr.Elem()
+	}
+	if rs.api.nestedMaps && !rs.flatMaps && rs.api.columnSeparator != "" && rs.mapElementType.Kind() == reflect.Interface {
+		if err := rs.startNestedMap(); err != nil {
+			return err
+		}
+	}
+	rs.scanFn = rs.scanMap
+	return nil
+}
+
+// startNestedMap splits columns by the column separator into paths of map keys.
+func (rs *RowScanner) startNestedMap() error {
+	rs.mapPaths = make([][]reflect.Value, len(rs.columns))
+	prefixes := make(map[string]string)
+	roots := make(map[string]struct{})
+	for i, column := range rs.columns {
+		parts := strings.Split(column, rs.api.columnSeparator)
+		if len(parts) == 1 {
+			continue
+		}
+		path := make([]reflect.Value, len(parts))
+		for j, part := range parts {
+			path[j] = reflect.ValueOf(part)
+			if j < len(parts)-1 {
+				prefixes[strings.Join(parts[:j+1], rs.api.columnSeparator)] = column
+			}
+		}
+		rs.mapPaths[i] = path
+		if _, ok := roots[parts[0]]; !ok {
+			roots[parts[0]] = struct{}{}
+			rs.mapNestedRoots = append(rs.mapNestedRoots, path[0])
+		}
+	}
+	for _, column := range rs.columns {
+		if nestedColumn, ok := prefixes[column]; ok {
+			return fmt.Errorf("scany: column '%s' conflicts with nested column '%s'", column, nestedColumn)
+		}
+	}
+	return nil
+}
+
+func (rs *RowScanner) columnScanTypes() ([]reflect.Type, error) {
+	if !rs.api.columnTypedMaps || rs.mapElementType.Kind() != reflect.Interface {
+		return nil, nil
+	}
+	typer, ok := rs.rows.(ColumnScanTyper)
+	if !ok {
+		return nil, nil
+	}
+	columnTypes, err := typer.ColumnScanTypes()
+	if err != nil {
+		return nil, fmt.Errorf("scany: get rows column types: %w", err)
+	}
+	if len(columnTypes) != len(rs.columns) {
+		return nil, fmt.Errorf("scany: number of column types must equal number of columns, got %d and %d",
+			len(columnTypes), len(rs.columns))
+	}
+	return columnTypes, nil
+}
+
 func (rs *RowScanner) scanMap(mapValue reflect.Value) error {
 	if mapValue.IsNil() {
-		mapValue.Set(reflect.MakeMap(mapValue.Type()))
+		mapValue.Set(reflect.MakeMapWithSize(mapValue.Type(), len(rs.columns)))
+	} else if rs.api.clearMapDestination {
+		iter := mapValue.MapRange()
+		for iter.Next() {
+			mapValue.SetMapIndex(iter.Key(), reflect.Value{})
+		}
 	}
 
-	if rs.scans == nil {
-		rs.scans = make([]interface{}, len(rs.columns))
+	// Nested maps are built from scratch for every row,
+	// so maps from the previous rows aren't modified.
+	for _, root := range rs.mapNestedRoots {
+		mapValue.SetMapIndex(root, reflect.Value{})
 	}
-	values := make([]reflect.Value, len(rs.columns))
-	for i := range rs.columns {
-		valuePtr := reflect.New(rs.mapElementType)
-		rs.scans[i] = valuePtr.Interface()
-		values[i] = valuePtr.Elem()
+	for _, value := range rs.mapValues {
+		value.Set(reflect.Zero(value.Type()))
 	}
 	if err := rs.rows.Scan(rs.scans...); err != nil {
 		return fmt.Errorf("scany: scan rows into map: %w", err)
@@ -181,14 +442,47 @@ func (rs *RowScanner) scanMap(mapValue reflect.Value) error {
 	// We can't set reflect values into destination map before scanning them,
 	// because reflect will set a copy, just like regular map behaves,
 	// and scan won't modify the map element.
-	for i, column := range rs.columns {
-		key := reflect.ValueOf(column)
-		value := values[i]
+	convertBytes := rs.api.mapBytesToString && rs.mapElementType.Kind() == reflect.Interface
+	for i, key := range rs.mapKeys {
+		value := rs.mapValues[i]
+		switch {
+		case rs.mapTypedValues[i]:
+			if value.IsNil() {
+				value = reflect.Zero(rs.mapElementType)
+			} else {
+				value = value.Elem()
+			}
+		case convertBytes:
+			if b, ok := value.Interface().([]byte); ok {
+				value = reflect.ValueOf(string(b))
+			}
+		}
+		if rs.mapPaths != nil && rs.mapPaths[i] != nil {
+			setNestedMapValue(mapValue, rs.mapPaths[i], value)
+			continue
+		}
 		mapValue.SetMapIndex(key, value)
 	}
 	return nil
 }
 
+// setNestedMapValue sets the value by the path of keys,
+// it creates nested maps of the same type as the root map on the way.
+func setNestedMapValue(mapValue reflect.Value, path []reflect.Value, value reflect.Value) {
+	for _, key := range path[:len(path)-1] {
+		nested := mapValue.MapIndex(key)
+		if nested.IsValid() && nested.Kind() == reflect.Interface {
+			nested = nested.Elem()
+		}
+		if !nested.IsValid() || nested.Type() != mapValue.Type() {
+			nested = reflect.MakeMap(mapValue.Type())
+			mapValue.SetMapIndex(key, nested)
+		}
+		mapValue = nested
+	}
+	mapValue.SetMapIndex(path[len(path)-1], value)
+}
+
 func (rs *RowScanner) scanPrimitive(value reflect.Value) error {
 	if rs.scans == nil {
 		rs.scans = make([]interface{}, 1)
@@ -201,8 +495,12 @@ func (rs *RowScanner) scanPrimitive(value reflect.Value) error {
 }
 
 func (rs *RowScanner) ensureDistinctColumns() error {
-	seen := make(map[string]struct{}, len(rs.columns))
-	for _, column := range rs.columns {
+	return ensureDistinctColumns(rs.columns)
+}
+
+func ensureDistinctColumns(columns []string) error {
+	seen := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
 		if _, ok := seen[column]; ok {
 			return fmt.Errorf("scany: rows contain a duplicate column '%s'", column)
 		}
@@ -3,6 +3,8 @@ package dbscan_test
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
 )
 
 type FooNested struct {
@@ -991,3 +994,371 @@ func TestRowScanner_Scan_startCalledExactlyOnce(t *testing.T) {
 	t.Parallel()
 	dbscan.DoTestRowScannerStartCalledExactlyOnce(t, testAPI, queryRows)
 }
+
+// generatedModel implements dbscan.ColumnPointerer the same way as code generated by scanygen does.
+type generatedModel struct {
+	Foo    string
+	Nested *FooNested
+}
+
+func (s *generatedModel) ColumnPointers(columns []string, pointers []interface{}) {
+	for i, column := range columns {
+		switch column {
+		case "foo":
+			pointers[i] = &s.Foo
+		case "nested.foo_nested":
+			if s.Nested == nil {
+				s.Nested = new(FooNested)
+			}
+			pointers[i] = &s.Nested.FooNested
+		}
+	}
+}
+
+func (*generatedModel) ColumnMapping() (owner interface{}, tagKey, separator string) {
+	return (*generatedModel)(nil), "db", "."
+}
+
+func TestRowScanner_Scan_columnPointererDestination(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"foo", "nested.foo_nested"},
+		Rows:    [][]interface{}{{"foo val", "foo nested val"}},
+	})
+	expected := generatedModel{Foo: "foo val", Nested: &FooNested{FooNested: "foo nested val"}}
+
+	var got generatedModel
+	err := scan(t, &got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRowScanner_Scan_columnPointererDestinationUnknownColumn(t *testing.T) {
+	t.Parallel()
+	newRows := func() dbscan.Rows {
+		return dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+			Columns: []string{"foo", "bar"},
+			Rows:    [][]interface{}{{"foo val", "bar val"}},
+		})
+	}
+
+	var got generatedModel
+	err := scan(t, &got, newRows())
+	assert.EqualError(t, err,
+		"doing scan: scanFn: scany: column: 'bar': no corresponding field found, or it's unexported in dbscan_test.generatedModel")
+
+	api, err := getAPI(dbscan.WithAllowUnknownColumns(true))
+	require.NoError(t, err)
+	rows := newRows()
+	rows.Next()
+	err = api.ScanRow(&got, rows)
+	require.NoError(t, err)
+	assert.Equal(t, generatedModel{Foo: "foo val"}, got)
+}
+
+type deepModel struct {
+	Level1 *deepLevel1
+	Foo    string
+}
+
+type deepLevel1 struct {
+	Level2 *deepLevel2
+	Foo    string
+}
+
+type deepLevel2 struct {
+	Level3 *deepLevel3
+	Foo    string
+}
+
+type deepLevel3 struct {
+	Foo string
+	Bar string
+}
+
+func TestRowScanner_Scan_deeplyNestedStructsByPtr(t *testing.T) {
+	t.Parallel()
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"level1.level2.level3.bar", "foo", "level1.foo"},
+		Rows:    [][]interface{}{{"bar val", "foo val", "foo val 1"}},
+	})
+	expected := deepModel{
+		Foo: "foo val",
+		Level1: &deepLevel1{
+			Foo:    "foo val 1",
+			Level2: &deepLevel2{Level3: &deepLevel3{Bar: "bar val"}},
+		},
+	}
+
+	var got deepModel
+	err := scan(t, &got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRowScanner_Scan_sameStructDifferentColumns(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI()
+	require.NoError(t, err)
+	type dst struct {
+		Foo string
+		Bar string
+	}
+	// The last two scans reuse the struct plans cached by the first two.
+	columnSets := [][]string{{"foo", "bar"}, {"bar", "foo"}, {"foo", "bar"}, {"bar", "foo"}}
+	for _, columns := range columnSets {
+		rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+			Columns: columns,
+			Rows:    [][]interface{}{{columns[0] + " val", columns[1] + " val"}},
+		})
+
+		var got dst
+		err := api.ScanOne(&got, rows)
+		require.NoError(t, err)
+
+		assert.Equal(t, dst{Foo: "foo val", Bar: "bar val"}, got)
+	}
+}
+
+func TestRowScanner_Scan_mapDestinationReusedAcrossRows(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name     string
+		opts     []dbscan.APIOption
+		expected []map[string]interface{}
+	}{
+		{
+			name: "default",
+			expected: []map[string]interface{}{
+				{"foo": "foo val", "bar": []byte("bar val"), "extra": "extra val"},
+				{"foo": "foo val 2", "bar": []byte("bar val 2"), "extra": "extra val"},
+			},
+		},
+		{
+			name: "clear map destination",
+			opts: []dbscan.APIOption{dbscan.WithClearMapDestination(true)},
+			expected: []map[string]interface{}{
+				{"foo": "foo val", "bar": []byte("bar val")},
+				{"foo": "foo val 2", "bar": []byte("bar val 2")},
+			},
+		},
+		{
+			name: "map bytes to string",
+			opts: []dbscan.APIOption{dbscan.WithMapBytesToString(true)},
+			expected: []map[string]interface{}{
+				{"foo": "foo val", "bar": "bar val", "extra": "extra val"},
+				{"foo": "foo val 2", "bar": "bar val 2", "extra": "extra val"},
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.opts...)
+			require.NoError(t, err)
+			rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+				Columns: []string{"foo", "bar"},
+				Rows: [][]interface{}{
+					{"foo val", []byte("bar val")},
+					{"foo val 2", []byte("bar val 2")},
+				},
+			})
+			rs := api.NewRowScanner(rows)
+			dst := map[string]interface{}{"extra": "extra val"}
+			var got []map[string]interface{}
+			for rows.Next() {
+				err := rs.Scan(&dst)
+				require.NoError(t, err)
+				row := make(map[string]interface{}, len(dst))
+				for k, v := range dst {
+					row[k] = v
+				}
+				got = append(got, row)
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestRowScanner_Scan_columnTypedMapDestination(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	newRows := func() dbscan.Rows {
+		return dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+			Columns: []string{"foo", "bar", "created_at", "raw"},
+			Rows: [][]interface{}{
+				{makeStrPtr("foo val"), nil, &createdAt, []byte("raw val")},
+			},
+			ColumnTypes: []reflect.Type{reflect.TypeOf(""), reflect.TypeOf(int64(0)), reflect.TypeOf(time.Time{}), nil},
+		})
+	}
+	cases := []struct {
+		name     string
+		opts     []dbscan.APIOption
+		dst      interface{}
+		expected interface{}
+	}{
+		{
+			name: "column typed maps",
+			opts: []dbscan.APIOption{dbscan.WithColumnTypedMaps(true)},
+			dst:  &map[string]interface{}{},
+			expected: map[string]interface{}{
+				"foo": "foo val", "bar": nil, "created_at": createdAt, "raw": []byte("raw val"),
+			},
+		},
+		{
+			name: "disabled by default",
+			dst:  &map[string]interface{}{},
+			expected: map[string]interface{}{
+				"foo": makeStrPtr("foo val"), "bar": nil, "created_at": &createdAt, "raw": []byte("raw val"),
+			},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.opts...)
+			require.NoError(t, err)
+			rows := newRows()
+			require.True(t, rows.Next())
+			err = api.NewRowScanner(rows).Scan(tc.dst)
+			require.NoError(t, err)
+			assertDestinationEqual(t, tc.expected, tc.dst)
+		})
+	}
+}
+
+func TestRowScanner_Scan_nestedMapDestination(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithNestedMaps(true))
+	require.NoError(t, err)
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"id", "user.id", "user.email", "user.address.city"},
+		Rows: [][]interface{}{
+			{"1", "user 1", "user1@example.com", "city 1"},
+			{"2", "user 2", "user2@example.com", "city 2"},
+		},
+	})
+	rs := api.NewRowScanner(rows)
+	dst := map[string]interface{}{}
+	var got []map[string]interface{}
+	for rows.Next() {
+		err := rs.Scan(&dst)
+		require.NoError(t, err)
+		got = append(got, map[string]interface{}{"id": dst["id"], "user": dst["user"]})
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"id": "1",
+			"user": map[string]interface{}{
+				"id": "user 1", "email": "user1@example.com",
+				"address": map[string]interface{}{"city": "city 1"},
+			},
+		},
+		{
+			"id": "2",
+			"user": map[string]interface{}{
+				"id": "user 2", "email": "user2@example.com",
+				"address": map[string]interface{}{"city": "city 2"},
+			},
+		},
+	}
+	assert.Equal(t, expected, got)
+}
+
+func TestRowScanner_Scan_nestedMapDestinationConflictingColumns_returnsErr(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithNestedMaps(true))
+	require.NoError(t, err)
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"user.id", "user"},
+		Rows:    [][]interface{}{{"user 1", "user"}},
+	})
+	rows.Next()
+	dst := map[string]interface{}{}
+
+	err = api.NewRowScanner(rows).Scan(&dst)
+
+	assert.EqualError(t, err, "doing scan: starting: starting map: scany: column 'user' conflicts with nested column 'user.id'")
+}
+
+func TestRowScanner_Scan_nestedMapDestinationNonInterfaceValues_keepsFlatKeys(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithNestedMaps(true))
+	require.NoError(t, err)
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"user.id"},
+		Rows:    [][]interface{}{{"user 1"}},
+	})
+	rows.Next()
+	dst := map[string]string{}
+
+	err = api.NewRowScanner(rows).Scan(&dst)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"user.id": "user 1"}, dst)
+}
+
+func TestRowScanner_Scan_promotedColumnPointerer_usesReflection(t *testing.T) {
+	t.Parallel()
+	type embeddingModel struct {
+		generatedModel
+		Bar string
+	}
+	rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+		Columns: []string{"foo", "bar"},
+		Rows:    [][]interface{}{{"foo val", "bar val"}},
+	})
+	expected := embeddingModel{generatedModel: generatedModel{Foo: "foo val"}, Bar: "bar val"}
+
+	var got embeddingModel
+	err := scan(t, &got, rows)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRowScanner_Scan_columnPointererCustomMapping_usesReflection(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name    string
+		opts    []dbscan.APIOption
+		columns []string
+	}{
+		{
+			name:    "field name mapper",
+			opts:    []dbscan.APIOption{dbscan.WithFieldNameMapper(strings.ToUpper)},
+			columns: []string{"FOO", "NESTED.FOONESTED"},
+		},
+		{
+			name:    "column separator",
+			opts:    []dbscan.APIOption{dbscan.WithColumnSeparator("__")},
+			columns: []string{"foo", "nested__foo_nested"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			api, err := getAPI(tc.opts...)
+			require.NoError(t, err)
+			rows := dbscantest.NewFakeRowsSets(dbscantest.ResultSet{
+				Columns: tc.columns,
+				Rows:    [][]interface{}{{"foo val", "foo nested val"}},
+			})
+			expected := generatedModel{Foo: "foo val", Nested: &FooNested{FooNested: "foo nested val"}}
+
+			var got generatedModel
+			rows.Next()
+			err = api.ScanRow(&got, rows)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, got)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package dbscan
 
 import (
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -88,6 +89,78 @@ func (api *API) buildColumnToFieldIndexMap(structType reflect.Type) map[string][
 	return result
 }
 
+type columnField struct {
+	column string
+	index  []int
+}
+
+func (api *API) getColumnFields(structType reflect.Type) []columnField {
+	resultIface, ok := api.columnFieldsCache.Load(structType)
+	if ok {
+		return resultIface.([]columnField)
+	}
+
+	result := api.buildColumnFields(structType)
+	resultIface, _ = api.columnFieldsCache.LoadOrStore(structType, result)
+	result = resultIface.([]columnField)
+	return result
+}
+
+func (api *API) buildColumnFields(structType reflect.Type) []columnField {
+	columnToFieldIndex := api.getColumnToFieldIndexMap(structType)
+	result := make([]columnField, 0, len(columnToFieldIndex))
+	for column, index := range columnToFieldIndex {
+		result = append(result, columnField{column: column, index: index})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return lessFieldIndex(result[i].index, result[j].index)
+	})
+
+	// The column to field index map contains columns for nested structs themselves, along with their fields.
+	// Only keep a nested struct if it's scannable or doesn't have any fields mapped, like time.Time.
+	// Thanks to the sorting, all fields of a nested struct follow it.
+	filtered := result[:0]
+	for i, f := range result {
+		if i+1 < len(result) && hasFieldIndexPrefix(result[i+1].index, f.index) {
+			fieldType := structType.FieldByIndex(f.index).Type
+			if !api.isScannableType(fieldType) {
+				continue
+			}
+		}
+		if len(filtered) > 0 {
+			last := filtered[len(filtered)-1]
+			fieldType := structType.FieldByIndex(last.index).Type
+			if hasFieldIndexPrefix(f.index, last.index) && api.isScannableType(fieldType) {
+				// Field belongs to a scannable nested struct, which is handled as a single column.
+				continue
+			}
+		}
+		filtered = append(filtered, f)
+	}
+	return filtered
+}
+
+func lessFieldIndex(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+func hasFieldIndexPrefix(index, prefix []int) bool {
+	if len(index) <= len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if index[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (api *API) buildColumn(parts ...string) string {
 	var notEmptyParts []string
 	for _, p := range parts {
@@ -97,17 +170,3 @@ func (api *API) buildColumn(parts ...string) string {
 	}
 	return strings.Join(notEmptyParts, api.columnSeparator)
 }
-
-func initializeNested(structValue reflect.Value, fieldIndex []int) {
-	i := fieldIndex[0]
-	field := structValue.Field(i)
-
-	// Create a new instance of a struct and set it to field,
-	// if field is a nil pointer to a struct.
-	if field.Kind() == reflect.Ptr && field.Type().Elem().Kind() == reflect.Struct && field.IsNil() {
-		field.Set(reflect.New(field.Type().Elem()))
-	}
-	if len(fieldIndex) > 1 {
-		initializeNested(reflect.Indirect(field), fieldIndex[1:])
-	}
-}
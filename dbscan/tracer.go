@@ -0,0 +1,62 @@
+package dbscan
+
+import (
+	"context"
+	"time"
+)
+
+// QueryTracer traces queries sent to the database by the high-level functions of sqlscan and pgxscan,
+// such as Select and Get. It allows to plug in OpenTelemetry spans or Prometheus metrics
+// without wrapping each querier. Use the WithQueryTracer option of the corresponding package to set it.
+type QueryTracer interface {
+	// QueryStart is called before the query is sent to the database.
+	// The returned context is used to send the query and is passed to QueryEnd,
+	// so it can carry a span, for example.
+	QueryStart(ctx context.Context, data QueryStartData) context.Context
+	// QueryEnd is called after all rows are scanned, or the query fails.
+	QueryEnd(ctx context.Context, data QueryEndData)
+}
+
+// QueryStartData describes a query that is about to be sent to the database.
+type QueryStartData struct {
+	// Method is the name of the function that sends the query, e.g. "Select", "Get" or "ExecReturning".
+	Method string
+	Query  string
+	Args   []interface{}
+}
+
+// QueryEndData describes the outcome of a query.
+type QueryEndData struct {
+	QueryStartData
+	// Rows is the number of rows read from the database.
+	Rows int
+	// QueryDuration is the time the database library took to return rows.
+	QueryDuration time.Duration
+	// ScanDuration is the time spent iterating rows and scanning them into the destination.
+	ScanDuration time.Duration
+	// Err is the error returned to the caller, nil if the query succeeded.
+	Err error
+}
+
+// QueryHooks is a QueryTracer that calls the provided functions, nil functions are skipped.
+type QueryHooks struct {
+	OnQueryStart func(ctx context.Context, data QueryStartData) context.Context
+	OnQueryEnd   func(ctx context.Context, data QueryEndData)
+}
+
+var _ QueryTracer = QueryHooks{}
+
+// QueryStart implements the QueryTracer.QueryStart method.
+func (qh QueryHooks) QueryStart(ctx context.Context, data QueryStartData) context.Context {
+	if qh.OnQueryStart == nil {
+		return ctx
+	}
+	return qh.OnQueryStart(ctx, data)
+}
+
+// QueryEnd implements the QueryTracer.QueryEnd method.
+func (qh QueryHooks) QueryEnd(ctx context.Context, data QueryEndData) {
+	if qh.OnQueryEnd != nil {
+		qh.OnQueryEnd(ctx, data)
+	}
+}
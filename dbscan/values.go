@@ -0,0 +1,310 @@
+package dbscan
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// PlaceholderFunc is a function type that renders a positional query placeholder for the n-th argument.
+// Arguments are numbered starting from 1.
+type PlaceholderFunc func(n int) string
+
+// DollarPlaceholder renders placeholders in the "$1" format used by PostgreSQL.
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// QuestionPlaceholder renders placeholders in the "?" format used by MySQL and SQLite.
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+// AtPPlaceholder renders placeholders in the "@p1" format used by SQL Server.
+func AtPPlaceholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+// ColonPlaceholder renders placeholders in the ":1" format used by Oracle.
+func ColonPlaceholder(n int) string {
+	return ":" + strconv.Itoa(n)
+}
+
+// Values is a package-level helper function that uses the DefaultAPI object.
+// See API.Values for details.
+func Values(src interface{}) ([]string, []interface{}, error) {
+	return DefaultAPI.Values(src)
+}
+
+// Values does the opposite of scanning: it extracts column names and the corresponding values from a struct.
+// It accepts a struct or a pointer to a struct and follows the same mapping rules as scanning does,
+// see the package docs for details.
+// Columns are returned in the order the fields are declared in the struct, nested and embedded structs included.
+// If a nested struct is a nil pointer, all its columns get nil values.
+//
+// Nested structs are traversed unless they are one of the scannable types, see WithScannableTypes,
+// so types like sql.NullString are returned as values as is.
+func (api *API) Values(src interface{}) ([]string, []interface{}, error) {
+	structValue, err := api.parseStructSource(src)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := api.getColumnFields(structValue.Type())
+	columns := make([]string, len(fields))
+	values := make([]interface{}, len(fields))
+	for i, f := range fields {
+		columns[i] = f.column
+		values[i], _ = fieldValueByIndex(structValue, f.index)
+	}
+	return columns, values, nil
+}
+
+// ValuesMap is a package-level helper function that uses the DefaultAPI object.
+// See API.ValuesMap for details.
+func ValuesMap(src interface{}) (map[string]interface{}, error) {
+	return DefaultAPI.ValuesMap(src)
+}
+
+// ValuesMap is like Values, but it returns struct values keyed by the column name.
+func (api *API) ValuesMap(src interface{}) (map[string]interface{}, error) {
+	columns, values, err := api.Values(src)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(columns))
+	for i, column := range columns {
+		result[column] = values[i]
+	}
+	return result, nil
+}
+
+// ValuesReader extracts values of a fixed list of columns from structs of the same type.
+// It does the mapping work once, so it's more efficient than calling Values for many structs,
+// e.g. when writing rows in bulk.
+type ValuesReader struct {
+	structType reflect.Type
+	columns    []string
+	fieldIndex [][]int
+}
+
+// NewValuesReader is a package-level helper function that uses the DefaultAPI object.
+// See API.NewValuesReader for details.
+func NewValuesReader(src interface{}, columns ...string) (*ValuesReader, error) {
+	return DefaultAPI.NewValuesReader(src, columns...)
+}
+
+// NewValuesReader returns a new ValuesReader for the type of src, which must be a struct or a pointer to a struct.
+// If no columns are provided, it reads all columns in the same order as Values does.
+// Otherwise, every column must have a corresponding struct field.
+func (api *API) NewValuesReader(src interface{}, columns ...string) (*ValuesReader, error) {
+	structValue, err := api.parseStructSource(src)
+	if err != nil {
+		return nil, err
+	}
+	structType := structValue.Type()
+	fields := api.getColumnFields(structType)
+	vr := &ValuesReader{structType: structType}
+	if len(columns) == 0 {
+		for _, f := range fields {
+			vr.columns = append(vr.columns, f.column)
+			vr.fieldIndex = append(vr.fieldIndex, f.index)
+		}
+		return vr, nil
+	}
+	columnToFieldIndex := make(map[string][]int, len(fields))
+	for _, f := range fields {
+		columnToFieldIndex[f.column] = f.index
+	}
+	for _, column := range columns {
+		fieldIndex, ok := columnToFieldIndex[column]
+		if !ok {
+			return nil, fmt.Errorf(
+				"scany: column: '%s': no corresponding field found, or it's unexported in %v",
+				column, structType,
+			)
+		}
+		vr.columns = append(vr.columns, column)
+		vr.fieldIndex = append(vr.fieldIndex, fieldIndex)
+	}
+	return vr, nil
+}
+
+// Columns returns the list of columns that ValuesReader reads.
+func (vr *ValuesReader) Columns() []string {
+	return vr.columns
+}
+
+// Values returns values of the ValuesReader columns from src.
+// src must be a struct or a pointer to a struct of the same type ValuesReader was created for.
+func (vr *ValuesReader) Values(src interface{}) ([]interface{}, error) {
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return nil, fmt.Errorf("scany: source must be a non nil pointer")
+		}
+		srcValue = srcValue.Elem()
+	}
+	if !srcValue.IsValid() || srcValue.Type() != vr.structType {
+		return nil, fmt.Errorf("scany: source must be of type %v, got: %T", vr.structType, src)
+	}
+	values := make([]interface{}, len(vr.fieldIndex))
+	for i, fieldIndex := range vr.fieldIndex {
+		values[i], _ = fieldValueByIndex(srcValue, fieldIndex)
+	}
+	return values, nil
+}
+
+// InsertQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.InsertQuery for details.
+func InsertQuery(table string, src interface{}, placeholderFn PlaceholderFunc) (string, []interface{}, error) {
+	return DefaultAPI.InsertQuery(table, src, placeholderFn)
+}
+
+// InsertQuery renders an "INSERT INTO table (columns) VALUES (placeholders)" statement for the source struct
+// and returns it together with the arguments. Columns and arguments are obtained the same way as Values does it,
+// except that columns of nested structs that are nil pointers are left out, so the database applies its defaults.
+// placeholderFn defines the placeholder format of the database, e.g. DollarPlaceholder.
+// Table and column names are rendered as is, without any quoting. That includes columns of nested structs,
+// e.g. "nested.column", most databases require them to be quoted via the struct tag
+// or a different separator set with WithColumnSeparator.
+func (api *API) InsertQuery(table string, src interface{}, placeholderFn PlaceholderFunc) (string, []interface{}, error) {
+	structValue, err := api.parseStructSource(src)
+	if err != nil {
+		return "", nil, err
+	}
+	var columns []string
+	var values []interface{}
+	for _, f := range api.getColumnFields(structValue.Type()) {
+		value, ok := fieldValueByIndex(structValue, f.index)
+		if !ok {
+			continue
+		}
+		columns = append(columns, f.column)
+		values = append(values, value)
+	}
+	if len(columns) == 0 {
+		return "", nil, fmt.Errorf("scany: no columns found in %T", src)
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = placeholderFn(i + 1)
+	}
+	query := "INSERT INTO " + table +
+		" (" + strings.Join(columns, ", ") + ")" +
+		" VALUES (" + strings.Join(placeholders, ", ") + ")"
+	return query, values, nil
+}
+
+// UpdateQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.UpdateQuery for details.
+func UpdateQuery(
+	table string, src interface{}, placeholderFn PlaceholderFunc, keyColumns ...string,
+) (string, []interface{}, error) {
+	return DefaultAPI.UpdateQuery(table, src, placeholderFn, keyColumns...)
+}
+
+// UpdateQuery renders an "UPDATE table SET column = placeholder, ... WHERE key = placeholder AND ..." statement
+// for the source struct and returns it together with the arguments.
+// Key columns identify the row to update, they go to the WHERE clause and all other columns go to the SET list.
+// At least one key column is required and every key column must have a corresponding struct field.
+// Columns and arguments are obtained the same way as Values does it, SET arguments come first,
+// followed by the key arguments. Columns of nested structs that are nil pointers are left out of the SET list,
+// so they keep their current values, and a key column of such a struct is an error.
+// placeholderFn defines the placeholder format of the database, e.g. DollarPlaceholder.
+// Table and column names are rendered as is, without any quoting. That includes columns of nested structs,
+// e.g. "nested.column", most databases require them to be quoted via the struct tag
+// or a different separator set with WithColumnSeparator.
+func (api *API) UpdateQuery(
+	table string, src interface{}, placeholderFn PlaceholderFunc, keyColumns ...string,
+) (string, []interface{}, error) {
+	if len(keyColumns) == 0 {
+		return "", nil, fmt.Errorf("scany: at least one key column is required")
+	}
+	structValue, err := api.parseStructSource(src)
+	if err != nil {
+		return "", nil, err
+	}
+	keyValues := make(map[string]interface{}, len(keyColumns))
+	for _, column := range keyColumns {
+		keyValues[column] = nil
+	}
+	var columns []string
+	var setList []string
+	var args []interface{}
+	for _, f := range api.getColumnFields(structValue.Type()) {
+		value, ok := fieldValueByIndex(structValue, f.index)
+		_, isKey := keyValues[f.column]
+		if !ok {
+			if isKey {
+				return "", nil, fmt.Errorf(
+					"scany: key column: '%s': the nested struct it belongs to is a nil pointer in %T", f.column, src,
+				)
+			}
+			continue
+		}
+		columns = append(columns, f.column)
+		if isKey {
+			keyValues[f.column] = value
+			continue
+		}
+		args = append(args, value)
+		setList = append(setList, f.column+" = "+placeholderFn(len(args)))
+	}
+	if len(setList) == 0 {
+		return "", nil, fmt.Errorf("scany: no columns to update found in %T", src)
+	}
+	conditions := make([]string, len(keyColumns))
+	for i, column := range keyColumns {
+		if !containsString(columns, column) {
+			return "", nil, fmt.Errorf(
+				"scany: key column: '%s': no corresponding field found, or it's unexported in %T", column, src,
+			)
+		}
+		args = append(args, keyValues[column])
+		conditions[i] = column + " = " + placeholderFn(len(args))
+	}
+	query := "UPDATE " + table +
+		" SET " + strings.Join(setList, ", ") +
+		" WHERE " + strings.Join(conditions, " AND ")
+	return query, args, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) parseStructSource(src interface{}) (reflect.Value, error) {
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Ptr {
+		if srcValue.IsNil() {
+			return reflect.Value{}, fmt.Errorf("scany: source must be a non nil pointer")
+		}
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("scany: source must be a struct or a pointer to a struct, got: %T", src)
+	}
+	return srcValue, nil
+}
+
+// fieldValueByIndex is like reflect.Value.FieldByIndex,
+// but instead of panicking it returns nil and false if one of the nested structs is a nil pointer.
+func fieldValueByIndex(structValue reflect.Value, fieldIndex []int) (interface{}, bool) {
+	v := structValue
+	for i, x := range fieldIndex {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Interface(), true
+}
@@ -0,0 +1,319 @@
+package dbscan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	cases := []struct {
+		name            string
+		src             interface{}
+		expectedColumns []string
+		expectedValues  []interface{}
+	}{
+		{
+			name: "struct by value",
+			src: struct {
+				Foo string
+				Bar int
+			}{Foo: "foo val", Bar: 1},
+			expectedColumns: []string{"foo", "bar"},
+			expectedValues:  []interface{}{"foo val", 1},
+		},
+		{
+			name: "struct by ptr",
+			src: &struct {
+				Foo string
+				Bar int
+			}{Foo: "foo val", Bar: 1},
+			expectedColumns: []string{"foo", "bar"},
+			expectedValues:  []interface{}{"foo val", 1},
+		},
+		{
+			name: "tags and ignored fields",
+			src: &struct {
+				Foo     string `db:"foo_column"`
+				Bar     string `db:"-"`
+				Baz     string `db:"baz_column,omitempty"`
+				private string
+			}{Foo: "foo val", Bar: "bar val", Baz: "baz val", private: "private val"},
+			expectedColumns: []string{"foo_column", "baz_column"},
+			expectedValues:  []interface{}{"foo val", "baz val"},
+		},
+		{
+			name: "embedded and nested structs",
+			src: &struct {
+				FooNested
+				Bar       string
+				BarNested BarNested
+			}{FooNested: FooNested{FooNested: "foo val"}, Bar: "bar val", BarNested: BarNested{BarNested: "bar nested val"}},
+			expectedColumns: []string{"foo_nested", "bar", "bar_nested.bar_nested"},
+			expectedValues:  []interface{}{"foo val", "bar val", "bar nested val"},
+		},
+		{
+			name: "nil nested struct by ptr",
+			src: &struct {
+				Foo       string
+				BarNested *BarNested
+			}{Foo: "foo val"},
+			expectedColumns: []string{"foo", "bar_nested.bar_nested"},
+			expectedValues:  []interface{}{"foo val", nil},
+		},
+		{
+			name: "scannable and non traversable structs",
+			src: &struct {
+				Foo       pgtype.Text
+				CreatedAt time.Time
+			}{Foo: pgtype.Text{String: "foo val", Valid: true}, CreatedAt: createdAt},
+			expectedColumns: []string{"foo", "created_at"},
+			expectedValues:  []interface{}{pgtype.Text{String: "foo val", Valid: true}, createdAt},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			columns, values, err := testAPI.Values(tc.src)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedColumns, columns)
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
+
+func TestValues_customColumnSeparator(t *testing.T) {
+	t.Parallel()
+	api, err := getAPI(dbscan.WithColumnSeparator("__"))
+	require.NoError(t, err)
+	src := &struct {
+		BarNested BarNested
+	}{BarNested: BarNested{BarNested: "bar nested val"}}
+
+	columns, values, err := api.Values(src)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bar_nested__bar_nested"}, columns)
+	assert.Equal(t, []interface{}{"bar nested val"}, values)
+}
+
+func TestValues_invalidSource_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		src         interface{}
+		expectedErr string
+	}{
+		{
+			name:        "non struct",
+			src:         "foo",
+			expectedErr: "scany: source must be a struct or a pointer to a struct, got: string",
+		},
+		{
+			name:        "nil ptr",
+			src:         (*testModel)(nil),
+			expectedErr: "scany: source must be a non nil pointer",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := testAPI.Values(tc.src)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestInsertQuery(t *testing.T) {
+	t.Parallel()
+	src := &testModel{Foo: "foo val", Bar: "bar val"}
+
+	query, args, err := testAPI.InsertQuery("test_table", src, dbscan.DollarPlaceholder)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO test_table (foo, bar) VALUES ($1, $2)", query)
+	assert.Equal(t, []interface{}{"foo val", "bar val"}, args)
+}
+
+func TestInsertQuery_nilNestedStruct_leavesColumnsOut(t *testing.T) {
+	t.Parallel()
+	src := &struct {
+		Foo       string
+		BarNested *BarNested
+	}{Foo: "foo val"}
+
+	query, args, err := testAPI.InsertQuery("test_table", src, dbscan.DollarPlaceholder)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO test_table (foo) VALUES ($1)", query)
+	assert.Equal(t, []interface{}{"foo val"}, args)
+}
+
+func TestUpdateQuery(t *testing.T) {
+	t.Parallel()
+	type Src struct {
+		ID   string
+		Foo  string
+		Bar  string
+		Tags string `db:"-"`
+	}
+	src := &Src{ID: "id val", Foo: "foo val", Bar: "bar val"}
+	cases := []struct {
+		name          string
+		keyColumns    []string
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "single key column",
+			keyColumns:    []string{"id"},
+			expectedQuery: "UPDATE test_table SET foo = $1, bar = $2 WHERE id = $3",
+			expectedArgs:  []interface{}{"foo val", "bar val", "id val"},
+		},
+		{
+			name:          "multiple key columns",
+			keyColumns:    []string{"bar", "id"},
+			expectedQuery: "UPDATE test_table SET foo = $1 WHERE bar = $2 AND id = $3",
+			expectedArgs:  []interface{}{"foo val", "bar val", "id val"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			query, args, err := dbscan.UpdateQuery("test_table", src, dbscan.DollarPlaceholder, tc.keyColumns...)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestUpdateQuery_nilNestedStruct_leavesColumnsOut(t *testing.T) {
+	t.Parallel()
+	src := &struct {
+		ID        string
+		Foo       string
+		BarNested *BarNested
+	}{ID: "id val", Foo: "foo val"}
+
+	query, args, err := testAPI.UpdateQuery("test_table", src, dbscan.DollarPlaceholder, "id")
+	require.NoError(t, err)
+
+	assert.Equal(t, "UPDATE test_table SET foo = $1 WHERE id = $2", query)
+	assert.Equal(t, []interface{}{"foo val", "id val"}, args)
+}
+
+func TestUpdateQuery_keyColumnOfNilNestedStruct_returnsErr(t *testing.T) {
+	t.Parallel()
+	type Src struct {
+		Foo       string
+		BarNested *BarNested
+	}
+
+	_, _, err := testAPI.UpdateQuery("test_table", &Src{}, dbscan.DollarPlaceholder, "bar_nested.bar_nested")
+
+	expectedErr := "scany: key column: 'bar_nested.bar_nested': the nested struct it belongs to is a nil pointer in *dbscan_test.Src"
+	assert.EqualError(t, err, expectedErr)
+}
+
+func TestUpdateQuery_invalidKeyColumns_returnsErr(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name        string
+		keyColumns  []string
+		expectedErr string
+	}{
+		{
+			name:        "no key columns",
+			expectedErr: "scany: at least one key column is required",
+		},
+		{
+			name:        "unknown key column",
+			keyColumns:  []string{"baz"},
+			expectedErr: "scany: key column: 'baz': no corresponding field found, or it's unexported in *dbscan_test.testModel",
+		},
+		{
+			name:        "all columns are keys",
+			keyColumns:  []string{"foo", "bar"},
+			expectedErr: "scany: no columns to update found in *dbscan_test.testModel",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := dbscan.UpdateQuery("test_table", &testModel{}, dbscan.DollarPlaceholder, tc.keyColumns...)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestValuesReader(t *testing.T) {
+	t.Parallel()
+	type Src struct {
+		Foo       string
+		Bar       string
+		BarNested *BarNested
+	}
+	cases := []struct {
+		name            string
+		columns         []string
+		src             interface{}
+		expectedColumns []string
+		expectedValues  []interface{}
+	}{
+		{
+			name:            "all columns",
+			src:             &Src{Foo: "foo val", Bar: "bar val", BarNested: &BarNested{BarNested: "bar nested val"}},
+			expectedColumns: []string{"foo", "bar", "bar_nested.bar_nested"},
+			expectedValues:  []interface{}{"foo val", "bar val", "bar nested val"},
+		},
+		{
+			name:            "subset of columns",
+			columns:         []string{"bar_nested.bar_nested", "foo"},
+			src:             Src{Foo: "foo val", Bar: "bar val"},
+			expectedColumns: []string{"bar_nested.bar_nested", "foo"},
+			expectedValues:  []interface{}{nil, "foo val"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			vr, err := testAPI.NewValuesReader(&Src{}, tc.columns...)
+			require.NoError(t, err)
+			values, err := vr.Values(tc.src)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedColumns, vr.Columns())
+			assert.Equal(t, tc.expectedValues, values)
+		})
+	}
+}
+
+func TestValuesReader_unknownColumn_returnsErr(t *testing.T) {
+	t.Parallel()
+	_, err := testAPI.NewValuesReader(&testModel{}, "foo", "baz")
+
+	assert.EqualError(t, err, "scany: column: 'baz': no corresponding field found, or it's unexported in dbscan_test.testModel")
+}
+
+func TestValuesReader_otherType_returnsErr(t *testing.T) {
+	t.Parallel()
+	vr, err := testAPI.NewValuesReader(&testModel{})
+	require.NoError(t, err)
+
+	_, err = vr.Values(&FooNested{})
+
+	assert.EqualError(t, err, "scany: source must be of type dbscan_test.testModel, got: *dbscan_test.FooNested")
+}
@@ -0,0 +1,31 @@
+// Package crdbtx contains the transaction retry logic shared by sqlscan and pgxscan.
+package crdbtx
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+)
+
+var errTxPanicked = errors.New("scany: transaction function panicked")
+
+// ExecuteInTx calls crdb.ExecuteInTx and makes sure that the transaction is rolled back if fn panics,
+// since crdb.ExecuteInTx commits the transaction in that case. The panic is propagated after the rollback.
+func ExecuteInTx(ctx context.Context, tx crdb.Tx, fn func() error) error {
+	var panicValue interface{}
+	panicked := false
+	err := crdb.ExecuteInTx(ctx, tx, func() (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				panicValue, panicked = p, true
+				err = errTxPanicked
+			}
+		}()
+		return fn()
+	})
+	if panicked {
+		panic(panicValue)
+	}
+	return err
+}
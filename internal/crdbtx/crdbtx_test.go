@@ -0,0 +1,57 @@
+package crdbtx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/georgysavva/scany/v2/internal/crdbtx"
+)
+
+type fakeTx struct {
+	execs      []string
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Exec(_ context.Context, query string, _ ...interface{}) error {
+	tx.execs = append(tx.execs, query)
+	return nil
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+func TestExecuteInTx_panic_rollsBack(t *testing.T) {
+	t.Parallel()
+	tx := &fakeTx{}
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = crdbtx.ExecuteInTx(context.Background(), tx, func() error {
+			panic("boom")
+		})
+	})
+
+	assert.False(t, tx.committed)
+	assert.True(t, tx.rolledBack)
+}
+
+func TestExecuteInTx_success_commits(t *testing.T) {
+	t.Parallel()
+	tx := &fakeTx{}
+
+	err := crdbtx.ExecuteInTx(context.Background(), tx, func() error { return nil })
+
+	assert.NoError(t, err)
+	assert.True(t, tx.committed)
+	assert.False(t, tx.rolledBack)
+	assert.Equal(t, []string{"SAVEPOINT cockroach_restart", "RELEASE SAVEPOINT cockroach_restart"}, tx.execs)
+}
@@ -0,0 +1,37 @@
+// Package notfound makes dbscan not found errors recognizable by the database libraries' checks,
+// it's shared by sqlscan and pgxscan.
+package notfound
+
+import (
+	"errors"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Wrap returns err as is, unless it's a dbscan not found error.
+// In that case it returns an error that keeps the message and the chain of err
+// and also matches libErr via errors.Is, e.g. sql.ErrNoRows or pgx.ErrNoRows,
+// so both dbscan.NotFound and the check of the database library report it.
+func Wrap(err, libErr error) error {
+	if !dbscan.NotFound(err) {
+		return err
+	}
+	return &notFoundError{err: err, libErr: libErr}
+}
+
+type notFoundError struct {
+	err    error
+	libErr error
+}
+
+func (nfe *notFoundError) Error() string {
+	return nfe.err.Error()
+}
+
+func (nfe *notFoundError) Unwrap() error {
+	return nfe.err
+}
+
+func (nfe *notFoundError) Is(target error) bool {
+	return errors.Is(nfe.libErr, target)
+}
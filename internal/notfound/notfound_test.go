@@ -0,0 +1,32 @@
+package notfound_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/internal/notfound"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+	libErr := errors.New("lib: no rows")
+	err := fmt.Errorf("error processing destination 1: %w", dbscan.ErrNotFound)
+
+	got := notfound.Wrap(err, libErr)
+
+	assert.EqualError(t, got, "error processing destination 1: scany: no row was found")
+	assert.True(t, dbscan.NotFound(got))
+	assert.True(t, errors.Is(got, libErr))
+}
+
+func TestWrap_otherErr_returnsItAsIs(t *testing.T) {
+	t.Parallel()
+	err := errors.New("some error")
+
+	assert.Same(t, err, notfound.Wrap(err, errors.New("lib: no rows")))
+	assert.NoError(t, notfound.Wrap(nil, errors.New("lib: no rows")))
+}
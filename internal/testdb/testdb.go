@@ -0,0 +1,87 @@
+// Package testdb provides the CockroachDB test database shared by the scany test suites.
+package testdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/cockroach-go/v2/testserver"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Server starts a CockroachDB test server when the first connection to it is made,
+// so tests that don't use the database run without it.
+type Server struct {
+	// Prepare is called once after the server is started, e.g. to create types used by the tests.
+	Prepare func(ctx context.Context, conn *pgx.Conn) error
+
+	once   sync.Once
+	mu     sync.Mutex
+	ts     testserver.TestServer
+	config *pgx.ConnConfig
+	err    error
+}
+
+// Pool returns a pool of connections to the server.
+func (s *Server) Pool() (*pgxpool.Pool, error) {
+	config, err := pgxpool.ParseConfig("")
+	if err != nil {
+		return nil, fmt.Errorf("parse pool config: %w", err)
+	}
+	config.BeforeConnect = s.beforeConnect
+	return pgxpool.NewWithConfig(context.Background(), config)
+}
+
+// DB returns a database/sql handle to the server that uses the pgx driver.
+func (s *Server) DB() (*sql.DB, error) {
+	config, err := pgx.ParseConfig("")
+	if err != nil {
+		return nil, fmt.Errorf("parse connection config: %w", err)
+	}
+	return stdlib.OpenDB(*config, stdlib.OptionBeforeConnect(s.beforeConnect)), nil
+}
+
+// Stop stops the server if it was started.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ts != nil {
+		s.ts.Stop()
+	}
+}
+
+func (s *Server) beforeConnect(ctx context.Context, config *pgx.ConnConfig) error {
+	s.once.Do(func() { s.err = s.start(ctx) })
+	if s.err != nil {
+		return s.err
+	}
+	config.Config = s.config.Config
+	return nil
+}
+
+func (s *Server) start(ctx context.Context) error {
+	ts, err := testserver.NewTestServer()
+	if err != nil {
+		return fmt.Errorf("start test server: %w", err)
+	}
+	s.mu.Lock()
+	s.ts = ts
+	s.mu.Unlock()
+	s.config, err = pgx.ParseConfig(ts.PGURL().String())
+	if err != nil {
+		return fmt.Errorf("parse test server URL: %w", err)
+	}
+	if s.Prepare == nil {
+		return nil
+	}
+	conn, err := pgx.ConnectConfig(ctx, s.config)
+	if err != nil {
+		return fmt.Errorf("connect to test server: %w", err)
+	}
+	defer conn.Close(ctx) //nolint: errcheck
+	return s.Prepare(ctx, conn)
+}
@@ -0,0 +1,122 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BatchSender is something that pgxscan can send a batch of queries with.
+// For example, it can be: *pgxpool.Pool, *pgx.Conn or pgx.Tx.
+type BatchSender interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+var (
+	_ BatchSender = &pgxpool.Pool{}
+	_ BatchSender = &pgx.Conn{}
+	_ BatchSender = pgx.Tx(nil)
+)
+
+// Batch queues multiple queries, each paired with its own destination,
+// and sends them to the database in a single round trip.
+// Each query is handled either with Select or Get semantics, see Batch.Select and Batch.Get.
+// Batch must only be sent once.
+type Batch struct {
+	api     *API
+	queries []*batchQuery
+}
+
+type batchQuery struct {
+	query        string
+	args         []interface{}
+	dst          interface{}
+	multipleRows bool
+}
+
+// BatchError is returned by Batch.Send if one of the queued queries fails.
+// It allows to find out which query caused the error.
+type BatchError struct {
+	// Index is the position of the failed query in the batch, starting from 0.
+	Index int
+	// Query is the text of the failed query.
+	Query string
+	Err   error
+}
+
+// Error implements the error interface.
+func (be *BatchError) Error() string {
+	return fmt.Sprintf("scany: batch query %d: %v", be.Index, be.Err)
+}
+
+// Unwrap returns the underlying error.
+func (be *BatchError) Unwrap() error {
+	return be.Err
+}
+
+// NewBatch is a package-level helper function that uses the DefaultAPI object.
+// See API.NewBatch for details.
+func NewBatch() *Batch {
+	return DefaultAPI.NewBatch()
+}
+
+// NewBatch returns a new empty Batch that scans results with the API settings.
+func (api *API) NewBatch() *Batch {
+	return &Batch{api: api}
+}
+
+// Select queues a query whose result rows are scanned into dst, the same way as API.Select does it.
+func (b *Batch) Select(dst interface{}, query string, args ...interface{}) *Batch {
+	b.queries = append(b.queries, &batchQuery{query: query, args: args, dst: dst, multipleRows: true})
+	return b
+}
+
+// Get queues a query whose single result row is scanned into dst, the same way as API.Get does it.
+func (b *Batch) Get(dst interface{}, query string, args ...interface{}) *Batch {
+	b.queries = append(b.queries, &batchQuery{query: query, args: args, dst: dst, multipleRows: false})
+	return b
+}
+
+// Len returns the number of queries queued so far.
+func (b *Batch) Len() int {
+	return len(b.queries)
+}
+
+// Send sends all queued queries in one batch and scans the results into their destinations in order.
+// It stops at the first failed query and returns a *BatchError that contains its index.
+func (b *Batch) Send(ctx context.Context, db BatchSender) error {
+	pgxBatch := &pgx.Batch{}
+	for _, q := range b.queries {
+		pgxBatch.Queue(q.query, q.args...)
+	}
+	br := db.SendBatch(ctx, pgxBatch)
+	defer br.Close() //nolint: errcheck
+	for i, q := range b.queries {
+		if err := b.scanQuery(br, q); err != nil {
+			return &BatchError{Index: i, Query: q.query, Err: err}
+		}
+	}
+	if err := br.Close(); err != nil {
+		return fmt.Errorf("scany: close batch results: %w", err)
+	}
+	return nil
+}
+
+func (b *Batch) scanQuery(br pgx.BatchResults, q *batchQuery) error {
+	rows, err := br.Query()
+	if err != nil {
+		return fmt.Errorf("scany: query batch results: %w", err)
+	}
+	if q.multipleRows {
+		if err := b.api.ScanAll(q.dst, rows); err != nil {
+			return fmt.Errorf("scanning all: %w", err)
+		}
+		return nil
+	}
+	if err := b.api.ScanOne(q.dst, rows); err != nil {
+		return fmt.Errorf("scanning one: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestBatch_Send(t *testing.T) {
+	t.Parallel()
+	expected1 := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	expected2 := testModel{Foo: "foo val", Bar: "bar val"}
+	expected3 := "baz val"
+
+	var got1 []*testModel
+	var got2 testModel
+	var got3 string
+	b := testAPI.NewBatch().
+		Select(&got1, multipleRowsQuery).
+		Get(&got2, singleRowsQuery).
+		Get(&got3, `SELECT $1::text`, "baz val")
+	err := b.Send(ctx, testDB)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, b.Len())
+	assert.Equal(t, expected1, got1)
+	assert.Equal(t, expected2, got2)
+	assert.Equal(t, expected3, got3)
+}
+
+func TestBatch_Send_queryError_returnsBatchErr(t *testing.T) {
+	t.Parallel()
+	var got1 []*testModel
+	var got2 testModel
+	err := testAPI.NewBatch().
+		Select(&got1, multipleRowsQuery).
+		Get(&got2, noRowsQuery).
+		Send(ctx, testDB)
+
+	var batchErr *pgxscan.BatchError
+	require.True(t, errors.As(err, &batchErr))
+	assert.Equal(t, 1, batchErr.Index)
+	assert.Equal(t, noRowsQuery, batchErr.Query)
+	assert.True(t, pgxscan.NotFound(err))
+}
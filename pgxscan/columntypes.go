@@ -0,0 +1,59 @@
+package pgxscan
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	_ dbscan.ColumnScanTyper = RowsAdapter{}
+	_ dbscan.ColumnScanTyper = &ResultSetsAdapter{}
+)
+
+var oidScanTypes = map[uint32]reflect.Type{
+	pgtype.Int2OID:        reflect.TypeOf(int64(0)),
+	pgtype.Int4OID:        reflect.TypeOf(int64(0)),
+	pgtype.Int8OID:        reflect.TypeOf(int64(0)),
+	pgtype.Float4OID:      reflect.TypeOf(float64(0)),
+	pgtype.Float8OID:      reflect.TypeOf(float64(0)),
+	pgtype.BoolOID:        reflect.TypeOf(false),
+	pgtype.DateOID:        reflect.TypeOf(time.Time{}),
+	pgtype.TimestampOID:   reflect.TypeOf(time.Time{}),
+	pgtype.TimestamptzOID: reflect.TypeOf(time.Time{}),
+	pgtype.TextOID:        reflect.TypeOf(""),
+	pgtype.VarcharOID:     reflect.TypeOf(""),
+	pgtype.BPCharOID:      reflect.TypeOf(""),
+	pgtype.NameOID:        reflect.TypeOf(""),
+	pgtype.UUIDOID:        reflect.TypeOf(""),
+	pgtype.NumericOID:     reflect.TypeOf(""),
+}
+
+// ColumnScanTypes implements the dbscan.ColumnScanTyper.ColumnScanTypes method.
+// It maps each field to one of string, int64, float64, bool or time.Time based on its type OID.
+// Integer and floating point types are widened, numeric and uuid values are represented as strings.
+// Fields of other types, e.g. json or arrays, get a nil type and are decoded by pgx as usual.
+func (ra RowsAdapter) ColumnScanTypes() ([]reflect.Type, error) {
+	return columnScanTypes(ra.Rows.FieldDescriptions()), nil
+}
+
+// ColumnScanTypes implements the dbscan.ColumnScanTyper.ColumnScanTypes method.
+// See RowsAdapter.ColumnScanTypes for details.
+func (ra *ResultSetsAdapter) ColumnScanTypes() ([]reflect.Type, error) {
+	if ra.rr == nil {
+		return nil, nil
+	}
+	return columnScanTypes(ra.rr.FieldDescriptions()), nil
+}
+
+func columnScanTypes(fds []pgconn.FieldDescription) []reflect.Type {
+	scanTypes := make([]reflect.Type, len(fds))
+	for i, fd := range fds {
+		scanTypes[i] = oidScanTypes[fd.DataTypeOID]
+	}
+	return scanTypes
+}
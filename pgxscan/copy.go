@@ -0,0 +1,104 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// CopyFromer is something that pgxscan can bulk insert rows with using the Postgres copy protocol.
+// For example, it can be: *pgxpool.Pool, *pgx.Conn or pgx.Tx.
+type CopyFromer interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+var (
+	_ CopyFromer = &pgxpool.Pool{}
+	_ CopyFromer = &pgx.Conn{}
+	_ CopyFromer = pgx.Tx(nil)
+)
+
+// CopyFromSlice is a package-level helper function that uses the DefaultAPI object.
+// See API.CopyFromSlice for details.
+func CopyFromSlice(
+	ctx context.Context, db CopyFromer, table pgx.Identifier, src interface{}, columns ...string,
+) (int64, error) {
+	return DefaultAPI.CopyFromSlice(ctx, db, table, src, columns...)
+}
+
+// CopyFromSlice bulk inserts all elements of the source slice into the table via CopyFrom
+// and returns the number of rows copied.
+// The source must be a slice of structs or a slice of pointers to structs.
+// Columns and values are derived from the struct type the same way as dbscan.Values does it.
+// To copy only a subset of columns, list them explicitly,
+// otherwise all columns that the struct type is mapped to are copied.
+//
+// Rows are read from the slice one by one as pgx sends them,
+// no intermediate copy of the whole data is made.
+func (api *API) CopyFromSlice(
+	ctx context.Context, db CopyFromer, table pgx.Identifier, src interface{}, columns ...string,
+) (int64, error) {
+	rowSrc, err := api.newCopyFromSlice(src, columns)
+	if err != nil {
+		return 0, err
+	}
+	n, err := db.CopyFrom(ctx, table, rowSrc.vr.Columns(), rowSrc)
+	if err != nil {
+		return n, fmt.Errorf("scany: copy from slice: %w", err)
+	}
+	return n, nil
+}
+
+type copyFromSlice struct {
+	slice reflect.Value
+	vr    *dbscan.ValuesReader
+	idx   int
+	err   error
+}
+
+var _ pgx.CopyFromSource = &copyFromSlice{}
+
+func (api *API) newCopyFromSlice(src interface{}, columns []string) (*copyFromSlice, error) {
+	sliceValue := reflect.ValueOf(src)
+	if sliceValue.Kind() == reflect.Ptr && !sliceValue.IsNil() {
+		sliceValue = sliceValue.Elem()
+	}
+	if sliceValue.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("scany: source must be a slice, got: %T", src)
+	}
+	elemType := sliceValue.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	vr, err := api.dbscanAPI.NewValuesReader(reflect.New(elemType).Interface(), columns...)
+	if err != nil {
+		return nil, fmt.Errorf("creating values reader: %w", err)
+	}
+	return &copyFromSlice{slice: sliceValue, vr: vr, idx: -1}, nil
+}
+
+// Next implements the pgx.CopyFromSource.Next method.
+func (cs *copyFromSlice) Next() bool {
+	cs.idx++
+	return cs.err == nil && cs.idx < cs.slice.Len()
+}
+
+// Values implements the pgx.CopyFromSource.Values method.
+func (cs *copyFromSlice) Values() ([]interface{}, error) {
+	values, err := cs.vr.Values(cs.slice.Index(cs.idx).Interface())
+	if err != nil {
+		cs.err = fmt.Errorf("element %d: %w", cs.idx, err)
+		return nil, cs.err
+	}
+	return values, nil
+}
+
+// Err implements the pgx.CopyFromSource.Err method.
+func (cs *copyFromSlice) Err() error {
+	return cs.err
+}
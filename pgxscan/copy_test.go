@@ -0,0 +1,56 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFromSlice(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE copy_from_slice (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	src := []testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	n, err := testAPI.CopyFromSlice(ctx, testDB, pgx.Identifier{"copy_from_slice"}, src)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), n)
+	var got []testModel
+	err = testAPI.Select(ctx, testDB, &got, `SELECT foo, bar FROM copy_from_slice ORDER BY foo`)
+	require.NoError(t, err)
+	assert.Equal(t, src, got)
+}
+
+func TestCopyFromSlice_subsetOfColumns(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE copy_from_slice_subset (foo TEXT, bar TEXT)`)
+	require.NoError(t, err)
+	src := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+	}
+	expected := []*testModel{
+		{Foo: "foo val", Bar: ""},
+	}
+
+	n, err := testAPI.CopyFromSlice(ctx, testDB, pgx.Identifier{"copy_from_slice_subset"}, src, "foo")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), n)
+	var got []*testModel
+	err = testAPI.Select(ctx, testDB, &got, `SELECT foo, COALESCE(bar, '') AS bar FROM copy_from_slice_subset`)
+	require.NoError(t, err)
+	assert.Equal(t, expected, got)
+}
+
+func TestCopyFromSlice_nonSliceSource_returnsErr(t *testing.T) {
+	t.Parallel()
+	_, err := testAPI.CopyFromSlice(ctx, testDB, pgx.Identifier{"copy_from_slice"}, &testModel{})
+
+	assert.EqualError(t, err, "scany: source must be a slice, got: *pgxscan_test.testModel")
+}
@@ -15,6 +15,68 @@ To support this it has two high-level functions Select and Get,
 they accept anything that implements Querier interface and query rows from it.
 This means that they can be used with *pgxpool.Pool, *pgx.Conn or pgx.Tx.
 
+Named arguments
+
+pgx supports "@name" placeholders via pgx.NamedArgs.
+NamedArgs function builds pgx.NamedArgs from a struct using the same rules that dbscan uses to map columns,
+and SelectNamed and GetNamed functions do it on the fly:
+
+	var users []*User
+	pgxscan.SelectNamed(ctx, db, &users, `SELECT * FROM users WHERE email = @email`, &Filter{Email: "bob@example.com"})
+
+Batch queries
+
+Batch type sends multiple queries to the database in a single round trip,
+and scans the result of each query into its own destination:
+
+	var users []*User
+	var post Post
+	err := pgxscan.NewBatch().
+		Select(&users, `SELECT * FROM users`).
+		Get(&post, `SELECT * FROM posts WHERE id = $1`, postID).
+		Send(ctx, db)
+
+If one of the queries fails, Send returns a *BatchError with the index of that query.
+
+Multiple result sets
+
+pgx.Rows always contains a single result set. To scan results of a query with multiple statements,
+use SelectSets function, it executes the query via pgconn.PgConn.Exec in a single round trip
+and scans each result set into the corresponding destination:
+
+	var users []*User
+	var posts []*Post
+	err := pgxscan.SelectSets(ctx, conn, []interface{}{&users, &posts}, `SELECT * FROM users; SELECT * FROM posts`)
+
+Bulk insert
+
+CopyFromSlice function bulk inserts a slice of structs via the Postgres copy protocol.
+It derives the column list and row values from the struct type, following the same rules as scanning:
+
+	users := []*User{...}
+	pgxscan.CopyFromSlice(ctx, db, pgx.Identifier{"users"}, users)
+
+Modifying rows
+
+ExecReturning function executes a query that modifies rows, scans rows returned by its RETURNING clause
+into a slice of structs or maps, or a single destination, and returns the number of affected rows.
+ExecExpect function returns an error that wraps dbscan.ErrUnexpectedRowsAffected
+if the number of affected rows differs from the expected one:
+
+	var user User
+	n, err := pgxscan.ExecReturning(ctx, db, &user, `INSERT INTO users (name) VALUES ($1) RETURNING *`, "Bob")
+
+	err = pgxscan.ExecExpect(ctx, db, 1, nil, `UPDATE users SET name = $1 WHERE id = $2`, "Bob", userID)
+
+The number of affected rows is taken from pgconn.CommandTag, a nil destination ignores returned rows.
+
+Column typed maps
+
+By default, pgx decodes map[string]interface{} values into its own types, e.g. pgtype.Numeric for numeric columns.
+With dbscan.WithColumnTypedMaps option pgxscan chooses a plain Go type for each column from the field type OID:
+integers become int64, floating point numbers become float64, numeric, uuid and text types become string,
+date and timestamp types become time.Time, see RowsAdapter.ColumnScanTypes for details.
+
 Note about pgx custom types
 
 pgx has a concept of Postgres specific types pgtype: https://pkg.go.dev/github.com/jackc/pgx/v5/pgtype
@@ -34,5 +96,86 @@ pgx can't handle **pgtype.Text, since only *pgtype.Text implements pgx custom ty
 Supported pgx version
 
 pgxscan v2 only works with pgx v5. So the import path of your pgx must be: "github.com/jackc/pgx/v5".
+
+Tracing
+
+WithQueryTracer option sets a dbscan.QueryTracer that is called around every query sent by Select, Get and ExecReturning.
+It receives the query text and arguments, the number of rows read, query and scan durations and the error, if any:
+
+	tracer := dbscan.QueryHooks{
+		OnQueryEnd: func(ctx context.Context, data dbscan.QueryEndData) {
+			queryDuration.WithLabelValues(data.Method).Observe(data.QueryDuration.Seconds())
+		},
+	}
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithQueryTracer(tracer))
+
+Unlike pgx.QueryTracer configured on the connection, it also reports the time spent scanning and the number of rows.
+
+Interceptors
+
+WithInterceptors option wraps the call that sends a query to the Querier in Select, Get and other functions that query rows.
+An interceptor can change the query, e.g. add a comment with request metadata, send it to a different Querier, such as a read replica,
+or reject it without calling the next function:
+
+	addComment := func(next pgxscan.QueryFunc) pgxscan.QueryFunc {
+		return func(ctx context.Context, db pgxscan.Querier, query string, args ...interface{}) (pgx.Rows, error) {
+			return next(ctx, db, query+" -- service: users", args...)
+		}
+	}
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithInterceptors(addComment))
+
+Rows are scanned after the interceptor returns,
+so an interceptor must not cancel the context it passes to the next function, e.g. to apply a timeout.
+
+Query in errors
+
+WithQueryInErrors option wraps errors returned by Select, Get and other functions that query rows into *dbscan.QueryError,
+that contains the query text truncated to the given length and redacted arguments. Use errors.As to access it:
+
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithQueryInErrors(200, dbscan.RedactAll))
+
+	var queryErr *dbscan.QueryError
+	if errors.As(err, &queryErr) {
+		log.Printf("query %q failed: %v", queryErr.Query, queryErr.Err)
+	}
+
+Arguments are masked by default, pass a custom dbscan.RedactFunc to only mask sensitive arguments.
+
+Retries
+
+WithRetryPolicy option makes Select and Get retry queries that fail with a transient error before any row is read,
+such as a serialization failure (SQLSTATE 40001) in CockroachDB or a lost connection:
+
+	api, err := pgxscan.NewAPI(dbscanAPI, pgxscan.WithRetryPolicy(dbscan.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     dbscan.ExponentialBackoff(10*time.Millisecond, time.Second),
+	}))
+
+Errors are classified by dbscan.IsRetryable unless a custom classifier is set.
+Retries stop when the context is done or its deadline comes before the next attempt.
+Queries are sent again as is, so only use retries with queries that are safe to repeat.
+Queries made on a pgx.Tx aren't retried, since the transaction is aborted after an error,
+use WithRetryableTx to retry the whole transaction instead.
+
+Transactions
+
+WithTx function runs a function in a transaction, it commits the transaction if the function succeeds
+and rolls it back if the function returns an error or panics:
+
+	err := pgxscan.WithTx(ctx, db, func(tx pgx.Tx) error {
+		if err := pgxscan.Get(ctx, tx, &user, `SELECT * FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+			return err
+		}
+		return pgxscan.Select(ctx, tx, &posts, `SELECT * FROM posts WHERE user_id = $1`, userID)
+	})
+
+WithRetryableTx function also retries the function on serialization failures,
+with the same semantics as crdb.ExecuteTx from github.com/cockroachdb/cockroach-go/v2/crdb.
+
+Testing
+
+Package pgxscantest provides FakeQuerier that returns canned rows for expected queries,
+so code that uses pgxscan can be unit tested without a database,
+see https://pkg.go.dev/github.com/georgysavva/scany/v2/pgxscan/pgxscantest for details.
 */
 package pgxscan
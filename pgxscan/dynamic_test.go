@@ -0,0 +1,22 @@
+package pgxscan_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectDynamic(t *testing.T) {
+	t.Parallel()
+	query := `SELECT 1::int8 AS id, 'foo val' AS foo, NULL::text AS bar, '{"a": 1}'::jsonb AS data`
+
+	got, gotType, err := testAPI.SelectDynamic(ctx, testDB, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Id", gotType.Field(0).Name)
+	gotJSON, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"id": 1, "foo": "foo val", "bar": null, "data": {"a": 1}}]`, string(gotJSON))
+}
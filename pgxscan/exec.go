@@ -0,0 +1,81 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) (int64, error) {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// ExecExpect is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecExpect for details.
+func ExecExpect(ctx context.Context, db Querier, n int64, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecExpect(ctx, db, n, dst, query, args...)
+}
+
+// ExecReturning executes a query that modifies rows, such as INSERT, UPDATE or DELETE,
+// scans the rows returned by its RETURNING clause into dst and returns the number of affected rows
+// reported by pgconn.CommandTag.
+// If dst is a pointer to a slice of structs or maps, all returned rows are scanned the same way as ScanAll does it,
+// otherwise exactly one row is expected and scanned the same way as ScanOne does it, see dbscan.IsRowsSlice.
+// So a pointer to a slice of primitive types, e.g. *[]byte or *[]string, receives a single column value,
+// to get a column of several rows, use a slice of structs.
+// If dst is nil, returned rows are ignored.
+func (api *API) ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) (int64, error) {
+	ctx, trace := api.startTrace(ctx, "ExecReturning", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scany: query returning rows: %w", err), query, args)
+		trace.end(nil, err)
+		return 0, err
+	}
+	if dst == nil {
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			err = api.queryError(fmt.Errorf("scany: exec query: %w", err), query, args)
+			trace.end(nil, err)
+			return 0, err
+		}
+		trace.end(nil, nil)
+		return rows.CommandTag().RowsAffected(), nil
+	}
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if api.dbscanAPI.IsRowsSlice(dst) {
+		err = api.scanAll(dst, cr)
+	} else {
+		err = api.scanOne(dst, cr)
+	}
+	// Rows are closed after scanning, so the command tag is available.
+	affected := rows.CommandTag().RowsAffected()
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scanning returning rows: %w", err), query, args)
+		trace.end(cr, err)
+		return affected, err
+	}
+	trace.end(cr, nil)
+	return affected, nil
+}
+
+// ExecExpect works like ExecReturning, but instead of returning the number of affected rows
+// it makes sure that the query affected exactly n rows, otherwise it returns an error
+// that wraps dbscan.ErrUnexpectedRowsAffected. The changes aren't reverted in that case,
+// run the query in a transaction, e.g. with WithTx, to roll them back.
+func (api *API) ExecExpect(ctx context.Context, db Querier, n int64, dst interface{}, query string, args ...interface{}) error {
+	affected, err := api.ExecReturning(ctx, db, dst, query, args...)
+	if err != nil {
+		return err
+	}
+	if affected != n {
+		return api.queryError(
+			fmt.Errorf("%w: expected %d, got: %d", dbscan.ErrUnexpectedRowsAffected, n, affected), query, args,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+type returnedUser struct {
+	ID   int
+	Name string
+}
+
+func TestExecReturning(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`INSERT INTO users (name) VALUES ($1), ($2) RETURNING id, name`).
+		WithArgs("Bob", "Alice").
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bob"}, {int64(2), "Alice"}})
+	db.ExpectQuery(`UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`).
+		WithArgs("Bobby", 1).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bobby"}})
+	db.ExpectQuery(`DELETE FROM users`).WillReturnRowsAffected(2)
+
+	var inserted []*returnedUser
+	n, err := pgxscan.ExecReturning(ctx, db, &inserted,
+		`INSERT INTO users (name) VALUES ($1), ($2) RETURNING id, name`, "Bob", "Alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, []*returnedUser{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Alice"}}, inserted)
+
+	var updated returnedUser
+	n, err = pgxscan.ExecReturning(ctx, db, &updated, `UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`, "Bobby", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, returnedUser{ID: 1, Name: "Bobby"}, updated)
+
+	n, err = pgxscan.ExecReturning(ctx, db, nil, `DELETE FROM users`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	db.AssertExpectations(t)
+}
+
+func TestExecReturning_noRowsForStruct_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`).
+		WithArgs("Bobby", 3).
+		WillReturnRows([]string{"id", "name"}, nil)
+
+	var updated returnedUser
+	n, err := pgxscan.ExecReturning(ctx, db, &updated, `UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`, "Bobby", 3)
+
+	assert.True(t, pgxscan.NotFound(err))
+	assert.Equal(t, int64(0), n)
+}
+
+func TestExecExpect(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`UPDATE users SET bio = NULL`).WillReturnRowsAffected(3).Times(2)
+
+	err := pgxscan.ExecExpect(ctx, db, 3, nil, `UPDATE users SET bio = NULL`)
+	require.NoError(t, err)
+
+	err = pgxscan.ExecExpect(ctx, db, 1, nil, `UPDATE users SET bio = NULL`)
+	assert.ErrorIs(t, err, dbscan.ErrUnexpectedRowsAffected)
+	assert.EqualError(t, err, "scany: unexpected number of affected rows: expected 1, got: 3")
+	db.AssertExpectations(t)
+}
+
+func TestExecReturning_nilDst_returnsCommandTagRowsAffected(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`UPDATE users SET bio = NULL RETURNING id`).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}}).
+		WillReturnRowsAffected(5)
+
+	n, err := pgxscan.ExecReturning(ctx, db, nil, `UPDATE users SET bio = NULL RETURNING id`)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5), n)
+	db.AssertExpectations(t)
+}
+
+func TestExecReturning_primitiveSliceDst_scansOneRow(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`UPDATE users SET tags = $1 WHERE id = $2 RETURNING tags`).
+		WillReturnRows([]string{"tags"}, [][]interface{}{{[]string{"foo", "bar"}}}).
+		WillReturnRowsAffected(1)
+	db.ExpectQuery(`UPDATE users SET avatar = $1 WHERE id = $2 RETURNING avatar`).
+		WillReturnRows([]string{"avatar"}, [][]interface{}{{[]byte("png")}}).
+		WillReturnRowsAffected(1)
+
+	var tags []string
+	n, err := pgxscan.ExecReturning(ctx, db, &tags,
+		`UPDATE users SET tags = $1 WHERE id = $2 RETURNING tags`, []string{"foo", "bar"}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []string{"foo", "bar"}, tags)
+
+	var avatar []byte
+	n, err = pgxscan.ExecReturning(ctx, db, &avatar,
+		`UPDATE users SET avatar = $1 WHERE id = $2 RETURNING avatar`, []byte("png"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []byte("png"), avatar)
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,75 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// SelectCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectCSV for details.
+func SelectCSV(ctx context.Context, db Querier, w io.Writer, query string, args ...interface{}) error {
+	return DefaultAPI.SelectCSV(ctx, db, w, query, args...)
+}
+
+// SelectNDJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectNDJSON for details.
+func SelectNDJSON(ctx context.Context, db Querier, w io.Writer, query string, args ...interface{}) error {
+	return DefaultAPI.SelectNDJSON(ctx, db, w, query, args...)
+}
+
+// WriteCSV is a package-level helper function that uses the DefaultAPI object.
+// See API.WriteCSV for details.
+func WriteCSV(w io.Writer, rows pgx.Rows, opts ...dbscan.WriteOption) error {
+	return DefaultAPI.WriteCSV(w, rows, opts...)
+}
+
+// WriteNDJSON is a package-level helper function that uses the DefaultAPI object.
+// See API.WriteNDJSON for details.
+func WriteNDJSON(w io.Writer, rows pgx.Rows, opts ...dbscan.WriteOption) error {
+	return DefaultAPI.WriteNDJSON(w, rows, opts...)
+}
+
+// SelectCSV is a high-level function that queries rows from Querier and calls the WriteCSV function
+// with the default write options. To customize the output, query rows and call WriteCSV directly.
+// See WriteCSV for details.
+func (api *API) SelectCSV(ctx context.Context, db Querier, w io.Writer, query string, args ...interface{}) error {
+	rows, err := api.queryFn(ctx, db, query, args...)
+	if err != nil {
+		return api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+	}
+	if err := api.WriteCSV(w, rows); err != nil {
+		return api.queryError(fmt.Errorf("writing csv: %w", err), query, args)
+	}
+	return nil
+}
+
+// SelectNDJSON is a high-level function that queries rows from Querier and calls the WriteNDJSON function
+// with the default write options. To customize the output, query rows and call WriteNDJSON directly.
+// See WriteNDJSON for details.
+func (api *API) SelectNDJSON(ctx context.Context, db Querier, w io.Writer, query string, args ...interface{}) error {
+	rows, err := api.queryFn(ctx, db, query, args...)
+	if err != nil {
+		return api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+	}
+	if err := api.WriteNDJSON(w, rows); err != nil {
+		return api.queryError(fmt.Errorf("writing ndjson: %w", err), query, args)
+	}
+	return nil
+}
+
+// WriteCSV is a wrapper around the dbscan.WriteCSV function.
+// See dbscan.WriteCSV for details.
+func (api *API) WriteCSV(w io.Writer, rows pgx.Rows, opts ...dbscan.WriteOption) error {
+	return api.dbscanAPI.WriteCSV(w, NewRowsAdapter(rows), opts...)
+}
+
+// WriteNDJSON is a wrapper around the dbscan.WriteNDJSON function.
+// See dbscan.WriteNDJSON for details.
+func (api *API) WriteNDJSON(w io.Writer, rows pgx.Rows, opts ...dbscan.WriteOption) error {
+	return api.dbscanAPI.WriteNDJSON(w, NewRowsAdapter(rows), opts...)
+}
@@ -0,0 +1,33 @@
+package pgxscan_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectCSV(t *testing.T) {
+	t.Parallel()
+	expected := "foo,bar\nfoo val,bar val\nfoo val 2,bar val 2\nfoo val 3,bar val 3\n"
+
+	var sb strings.Builder
+	err := testAPI.SelectCSV(ctx, testDB, &sb, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, sb.String())
+}
+
+func TestSelectNDJSON(t *testing.T) {
+	t.Parallel()
+	expected := `{"foo":"foo val","bar":"bar val"}` + "\n" +
+		`{"foo":"foo val 2","bar":"bar val 2"}` + "\n" +
+		`{"foo":"foo val 3","bar":"bar val 3"}` + "\n"
+
+	var sb strings.Builder
+	err := testAPI.SelectNDJSON(ctx, testDB, &sb, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, sb.String())
+}
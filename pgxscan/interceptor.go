@@ -0,0 +1,34 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryFunc sends a query to the database through the provided Querier.
+// Rows are scanned after QueryFunc returns, so the context must stay valid until then.
+type QueryFunc func(ctx context.Context, db Querier, query string, args ...interface{}) (pgx.Rows, error)
+
+// Interceptor wraps a QueryFunc to change how queries are sent, e.g. to rewrite the query text,
+// route it to a different Querier or reject it without calling next.
+type Interceptor func(next QueryFunc) QueryFunc
+
+// WithInterceptors adds interceptors that wrap every query sent by the API functions that query rows,
+// such as Select and Get. The first interceptor is the outermost one, it's called first.
+// Calling this option multiple times appends to the list of interceptors.
+func WithInterceptors(interceptors ...Interceptor) APIOption {
+	return func(api *API) {
+		api.interceptors = append(api.interceptors, interceptors...)
+	}
+}
+
+func buildQueryFunc(interceptors []Interceptor) QueryFunc {
+	queryFn := QueryFunc(func(ctx context.Context, db Querier, query string, args ...interface{}) (pgx.Rows, error) {
+		return db.Query(ctx, query, args...)
+	})
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		queryFn = interceptors[i](queryFn)
+	}
+	return queryFn
+}
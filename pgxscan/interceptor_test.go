@@ -0,0 +1,70 @@
+package pgxscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+func appendComment(comment string) pgxscan.Interceptor {
+	return func(next pgxscan.QueryFunc) pgxscan.QueryFunc {
+		return func(ctx context.Context, db pgxscan.Querier, query string, args ...interface{}) (pgx.Rows, error) {
+			return next(ctx, db, query+" /* "+comment+" */", args...)
+		}
+	}
+}
+
+func TestWithInterceptors_order(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t,
+		pgxscan.WithInterceptors(appendComment("first")),
+		pgxscan.WithInterceptors(appendComment("second")),
+	)
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id FROM users WHERE id = $1 /* first */ /* second */`).
+		WithArgs(1).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}})
+
+	var id int
+	err := api.Get(ctx, db, &id, `SELECT id FROM users WHERE id = $1`, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, id)
+	db.AssertExpectations(t)
+}
+
+func TestWithInterceptors_routesAndDenies(t *testing.T) {
+	t.Parallel()
+	replica := pgxscantest.NewFakeQuerier()
+	replica.ExpectQuery(`SELECT id FROM users`).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}})
+	errDenied := errors.New("query denied")
+	interceptor := func(next pgxscan.QueryFunc) pgxscan.QueryFunc {
+		return func(ctx context.Context, db pgxscan.Querier, query string, args ...interface{}) (pgx.Rows, error) {
+			if query == `DELETE FROM users RETURNING id` {
+				return nil, errDenied
+			}
+			return next(ctx, replica, query, args...)
+		}
+	}
+	api := newAPI(t, pgxscan.WithInterceptors(interceptor))
+	primary := pgxscantest.NewFakeQuerier()
+
+	var ids []int
+	err := api.Select(ctx, primary, &ids, `SELECT id FROM users`)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	err = api.Select(ctx, primary, &ids, `DELETE FROM users RETURNING id`)
+	assert.ErrorIs(t, err, errDenied)
+
+	replica.AssertExpectations(t)
+	primary.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NamedArgs is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedArgs for details.
+func NamedArgs(src interface{}) (pgx.NamedArgs, error) {
+	return DefaultAPI.NamedArgs(src)
+}
+
+// SelectNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectNamed for details.
+func SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.SelectNamed(ctx, db, dst, query, arg)
+}
+
+// GetNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.GetNamed for details.
+func GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.GetNamed(ctx, db, dst, query, arg)
+}
+
+// NamedArgs builds pgx.NamedArgs from a struct or a pointer to a struct.
+// Argument names are derived by the same rules that are used to map struct fields to columns,
+// see dbscan.Values for details. Nested struct fields are prefixed with the column separator,
+// note that pgx only recognizes letters, digits and underscores in "@name" placeholders,
+// so in order to reference nested fields use a compatible separator, e.g. dbscan.WithColumnSeparator("__").
+func (api *API) NamedArgs(src interface{}) (pgx.NamedArgs, error) {
+	values, err := api.dbscanAPI.ValuesMap(src)
+	if err != nil {
+		return nil, fmt.Errorf("getting struct values: %w", err)
+	}
+	return values, nil
+}
+
+// SelectNamed is like Select, but it accepts a query with "@name" placeholders
+// and binds them from a struct, a pointer to a struct or a map[string]interface{}.
+// See NamedArgs for details.
+func (api *API) SelectNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	namedArgs, err := api.toNamedArgs(arg)
+	if err != nil {
+		return err
+	}
+	return api.Select(ctx, db, dst, query, namedArgs)
+}
+
+// GetNamed is like Get, but it accepts a query with "@name" placeholders
+// and binds them from a struct, a pointer to a struct or a map[string]interface{}.
+// See NamedArgs for details.
+func (api *API) GetNamed(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	namedArgs, err := api.toNamedArgs(arg)
+	if err != nil {
+		return err
+	}
+	return api.Get(ctx, db, dst, query, namedArgs)
+}
+
+func (api *API) toNamedArgs(arg interface{}) (pgx.NamedArgs, error) {
+	switch v := arg.(type) {
+	case pgx.NamedArgs:
+		return v, nil
+	case map[string]interface{}:
+		return v, nil
+	default:
+		return api.NamedArgs(arg)
+	}
+}
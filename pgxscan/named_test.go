@@ -0,0 +1,85 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+func TestNamedArgs(t *testing.T) {
+	t.Parallel()
+	type Nested struct {
+		Baz string
+	}
+	type Arg struct {
+		Foo    string
+		Bar    string `db:"bar_column"`
+		Ignore string `db:"-"`
+		Nested Nested
+	}
+	arg := &Arg{Foo: "foo val", Bar: "bar val", Ignore: "ignore val", Nested: Nested{Baz: "baz val"}}
+	expected := pgx.NamedArgs{"foo": "foo val", "bar_column": "bar val", "nested.baz": "baz val"}
+
+	got, err := testAPI.NamedArgs(arg)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestSelectNamed(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	var got []*testModel
+	err := testAPI.SelectNamed(ctx, testDB, &got, `
+		SELECT *
+		FROM (
+			VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2'), ('foo val 3', 'bar val 3')
+		) AS t (foo, bar)
+		WHERE foo = @foo
+	`, &testModel{Foo: "foo val 2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.GetNamed(ctx, testDB, &got, `SELECT @foo::text AS foo, @bar::text AS bar`, map[string]interface{}{
+		"foo": "foo val",
+		"bar": "bar val",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGetNamed_nestedStruct(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := pgxscan.NewDBScanAPI(dbscan.WithColumnSeparator("__"))
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	type Arg struct {
+		Nested testModel
+	}
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err = api.GetNamed(ctx, testDB, &got, `SELECT @nested__foo::text AS foo, @nested__bar::text AS bar`, &Arg{
+		Nested: testModel{Foo: "foo val", Bar: "bar val"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
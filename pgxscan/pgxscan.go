@@ -48,6 +48,18 @@ func ScanOne(dst interface{}, rows pgx.Rows) error {
 	return DefaultAPI.ScanOne(dst, rows)
 }
 
+// InsertQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.InsertQuery for details.
+func InsertQuery(table string, src interface{}) (string, []interface{}, error) {
+	return DefaultAPI.InsertQuery(table, src)
+}
+
+// UpdateQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.UpdateQuery for details.
+func UpdateQuery(table string, src interface{}, keyColumns ...string) (string, []interface{}, error) {
+	return DefaultAPI.UpdateQuery(table, src, keyColumns...)
+}
+
 // RowScanner is a wrapper around the dbscan.RowScanner type.
 // See dbscan.RowScanner for details.
 type RowScanner struct {
@@ -81,52 +93,102 @@ func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
 // API is a wrapper around the dbscan.API type.
 // See dbscan.API for details.
 type API struct {
-	dbscanAPI *dbscan.API
+	dbscanAPI    *dbscan.API
+	tracer       dbscan.QueryTracer
+	interceptors []Interceptor
+	queryFn      QueryFunc
+	queryErrors  *queryErrorsConfig
+	retryPolicy  *dbscan.RetryPolicy
 }
 
-// NewAPI creates new API instance from dbscan.API instance.
-func NewAPI(dbscanAPI *dbscan.API) (*API, error) {
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
 	api := &API{dbscanAPI: dbscanAPI}
+	for _, o := range opts {
+		o(api)
+	}
+	api.queryFn = buildQueryFunc(api.interceptors)
 	return api, nil
 }
 
 // Select is a high-level function that queries rows from Querier and calls the ScanAll function.
 // See ScanAll for details.
 func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.Query(ctx, query, args...)
+	return api.retry(ctx, db, func() (bool, error) {
+		return api.selectOnce(ctx, db, dst, query, args)
+	})
+}
+
+// selectOnce sends the query once and reports whether any row was read, along with the error.
+func (api *API) selectOnce(ctx context.Context, db Querier, dst interface{}, query string, args []interface{}) (bool, error) {
+	ctx, trace := api.startTrace(ctx, "Select", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
 	if err != nil {
-		return fmt.Errorf("scany: query multiple result rows: %w", err)
+		err = api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+		trace.end(nil, err)
+		return false, err
 	}
-	if err := api.ScanAll(dst, rows); err != nil {
-		return fmt.Errorf("scanning all: %w", err)
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if err := api.scanAll(dst, cr); err != nil {
+		err = api.queryError(fmt.Errorf("scanning all: %w", err), query, args)
+		trace.end(cr, err)
+		return cr.count > 0, err
 	}
-	return nil
+	trace.end(cr, nil)
+	return cr.count > 0, nil
 }
 
 // Get is a high-level function that queries rows from Querier and calls the ScanOne function.
 // See ScanOne for details.
 func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.Query(ctx, query, args...)
+	return api.retry(ctx, db, func() (bool, error) {
+		return api.getOnce(ctx, db, dst, query, args)
+	})
+}
+
+// getOnce is like selectOnce, but it scans exactly one row like Get does it.
+func (api *API) getOnce(ctx context.Context, db Querier, dst interface{}, query string, args []interface{}) (bool, error) {
+	ctx, trace := api.startTrace(ctx, "Get", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
 	if err != nil {
-		return fmt.Errorf("scany: query one result row: %w", err)
+		err = api.queryError(fmt.Errorf("scany: query one result row: %w", err), query, args)
+		trace.end(nil, err)
+		return false, err
 	}
-	if err := api.ScanOne(dst, rows); err != nil {
-		return fmt.Errorf("scanning one: %w", err)
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if err := api.scanOne(dst, cr); err != nil {
+		err = api.queryError(fmt.Errorf("scanning one: %w", err), query, args)
+		trace.end(cr, err)
+		return cr.count > 0, err
 	}
-	return nil
+	trace.end(cr, nil)
+	return cr.count > 0, nil
 }
 
 // ScanAll is a wrapper around the dbscan.ScanAll function.
 // See dbscan.ScanAll for details.
 func (api *API) ScanAll(dst interface{}, rows pgx.Rows) error {
-	return api.dbscanAPI.ScanAll(dst, NewRowsAdapter(rows))
+	return api.scanAll(dst, NewRowsAdapter(rows))
+}
+
+func (api *API) scanAll(dst interface{}, rows dbscan.Rows) error {
+	return api.dbscanAPI.ScanAll(dst, rows)
 }
 
 // ScanOne is a wrapper around the dbscan.ScanOne function.
 // See dbscan.ScanOne for details. If no rows are found it
 // returns a pgx.ErrNoRows error.
 func (api *API) ScanOne(dst interface{}, rows pgx.Rows) error {
-	switch err := api.dbscanAPI.ScanOne(dst, NewRowsAdapter(rows)); {
+	return api.scanOne(dst, NewRowsAdapter(rows))
+}
+
+func (api *API) scanOne(dst interface{}, rows dbscan.Rows) error {
+	switch err := api.dbscanAPI.ScanOne(dst, rows); {
 	case dbscan.NotFound(err):
 		return fmt.Errorf("%w", pgx.ErrNoRows)
 	case err != nil:
@@ -136,6 +198,20 @@ func (api *API) ScanOne(dst interface{}, rows pgx.Rows) error {
 	}
 }
 
+// InsertQuery is a wrapper around the dbscan.InsertQuery function.
+// It renders placeholders in the "$1" format supported by pgx.
+// See dbscan.InsertQuery for details.
+func (api *API) InsertQuery(table string, src interface{}) (string, []interface{}, error) {
+	return api.dbscanAPI.InsertQuery(table, src, dbscan.DollarPlaceholder)
+}
+
+// UpdateQuery is a wrapper around the dbscan.UpdateQuery function.
+// It renders placeholders in the "$1" format supported by pgx.
+// See dbscan.UpdateQuery for details.
+func (api *API) UpdateQuery(table string, src interface{}, keyColumns ...string) (string, []interface{}, error) {
+	return api.dbscanAPI.UpdateQuery(table, src, dbscan.DollarPlaceholder, keyColumns...)
+}
+
 // NotFound is a helper function to check if an error
 // is `pgx.ErrNoRows`.
 func NotFound(err error) bool {
@@ -180,9 +256,9 @@ func (ra RowsAdapter) Close() error {
 	return nil
 }
 
-// NextResultSet is currently always returning false.
+// NextResultSet is always returning false, since pgx.Rows only contains a single result set.
+// Use ResultSetsAdapter to work with multiple result sets.
 func (ra RowsAdapter) NextResultSet() bool {
-	// TODO: when pgx issue #308 and #1512 and  is fixed mabye we can do something here.
 	return false
 }
 
@@ -194,8 +270,8 @@ func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
 	return api
 }
 
-func mustNewAPI(dbscanAPI *dbscan.API) *API {
-	api, err := NewAPI(dbscanAPI)
+func mustNewAPI(dbscanAPI *dbscan.API, opts ...APIOption) *API {
+	api, err := NewAPI(dbscanAPI, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -8,13 +8,13 @@ import (
 	"os"
 	"testing"
 
-	"github.com/cockroachdb/cockroach-go/v2/testserver"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/georgysavva/scany/v2/internal/testdb"
 	"github.com/georgysavva/scany/v2/pgxscan"
 )
 
@@ -214,6 +214,17 @@ func TestScanRow(t *testing.T) {
 	assert.Equal(t, expected, got)
 }
 
+func TestInsertQuery(t *testing.T) {
+	t.Parallel()
+	src := &testModel{Foo: "foo val", Bar: "bar val"}
+
+	query, args, err := testAPI.InsertQuery("test_table", src)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO test_table (foo, bar) VALUES ($1, $2)", query)
+	assert.Equal(t, []interface{}{"foo val", "bar val"}, args)
+}
+
 func getAPI() (*pgxscan.API, error) {
 	dbscanAPI, err := pgxscan.NewDBScanAPI()
 	if err != nil {
@@ -226,15 +237,24 @@ func getAPI() (*pgxscan.API, error) {
 	return api, nil
 }
 
+// newAPI returns an API with the given options for tests that run against pgxscantest.FakeQuerier.
+func newAPI(t *testing.T, opts ...pgxscan.APIOption) *pgxscan.API {
+	t.Helper()
+	dbscanAPI, err := pgxscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI, opts...)
+	require.NoError(t, err)
+	return api
+}
+
 func TestMain(m *testing.M) {
 	exitCode := func() int {
 		flag.Parse()
-		ts, err := testserver.NewTestServer()
-		if err != nil {
-			panic(err)
-		}
+		// The server is started by the first test that connects to testDB.
+		var ts testdb.Server
 		defer ts.Stop()
-		testDB, err = pgxpool.New(ctx, ts.PGURL().String())
+		var err error
+		testDB, err = ts.Pool()
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,33 @@
+// Package pgxscantest provides a fake pgxscan.Querier for unit tests.
+/*
+FakeQuerier returns canned rows for expected queries, so code that uses pgxscan can be tested without a database:
+
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id, name FROM users WHERE id = $1`).
+		WithArgs(1).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{1, "Bob"}})
+
+	var user User
+	err := pgxscan.Get(ctx, db, &user, `SELECT id, name FROM users WHERE id = $1`, 1)
+	db.AssertExpectations(t)
+
+Returned pgx.Rows convert values into destinations with database/sql rules, see dbscantest.FakeRows,
+which are more permissive than the rules pgx uses.
+See dbscantest.Expectations for details about matching queries.
+
+Golden tests
+
+RecordingQuerier wraps a real database connection and records queries along with their results,
+the recording is then saved to a golden file and replayed without a database by NewReplayQuerier:
+
+	if *update {
+		recorder := pgxscantest.NewRecordingQuerier(realDB)
+		runQueries(ctx, recorder)
+		err := recorder.Save("testdata/users.json")
+	}
+	db, err := pgxscantest.NewReplayQuerier("testdata/users.json")
+	runQueries(ctx, db)
+
+Replaying a query with a different text or arguments fails with an error that shows the difference.
+*/
+package pgxscantest
@@ -0,0 +1,155 @@
+package pgxscantest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+// FakeQuerier implements pgxscan.Querier and answers queries according to the expectations.
+// Queries that don't match any expectation fail with an error that describes the difference.
+type FakeQuerier struct {
+	dbscantest.Expectations
+}
+
+var _ pgxscan.Querier = &FakeQuerier{}
+
+// NewFakeQuerier returns a new FakeQuerier without any expectations.
+func NewFakeQuerier() *FakeQuerier {
+	return &FakeQuerier{}
+}
+
+// Query implements the pgxscan.Querier.Query method.
+// Only the first result set of the expectation is returned, since pgx.Rows doesn't support multiple result sets.
+// The command tag of the rows reports the number of rows set with dbscantest.Expectation.WillReturnRowsAffected
+// if the expectation has no result sets or that number is set explicitly,
+// otherwise it reports the number of rows read.
+func (fq *FakeQuerier) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	ex, err := fq.Match(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := ex.Err(); err != nil {
+		return nil, err
+	}
+	sets := ex.ResultSets()
+	if len(sets) == 0 {
+		rows := NewRows(dbscantest.ResultSet{})
+		rows.commandTag = pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", ex.RowsAffected()))
+		return rows, nil
+	}
+	rows := NewRows(sets[0])
+	if n := ex.RowsAffected(); n != 0 {
+		rows.commandTag = pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", n))
+	}
+	if afterRows, err := ex.RowsErr(); err != nil {
+		rows.WithErr(0, afterRows, err)
+	}
+	return rows, nil
+}
+
+// Exec returns a command tag with the number of rows set with dbscantest.Expectation.WillReturnRowsAffected.
+func (fq *FakeQuerier) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	ex, err := fq.Match(query, args)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	if err := ex.Err(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", ex.RowsAffected())), nil
+}
+
+// Rows is a fake implementation of pgx.Rows backed by dbscantest.FakeRows.
+type Rows struct {
+	*dbscantest.FakeRows
+	fields     []pgconn.FieldDescription
+	read       int
+	commandTag pgconn.CommandTag
+}
+
+var _ pgx.Rows = &Rows{}
+
+// NewRows returns new Rows over the result set.
+// Field type OIDs are derived from ResultSet.ColumnTypes when they are set,
+// so pgxscan chooses the same types for maps as it does for real rows.
+func NewRows(set dbscantest.ResultSet) *Rows {
+	fields := make([]pgconn.FieldDescription, len(set.Columns))
+	for i, column := range set.Columns {
+		fields[i].Name = column
+		if set.ColumnTypes != nil && set.ColumnTypes[i] != nil {
+			fields[i].DataTypeOID = typeOIDs[set.ColumnTypes[i]]
+		}
+	}
+	return &Rows{FakeRows: dbscantest.NewFakeRowsSets(set), fields: fields}
+}
+
+var typeOIDs = map[reflect.Type]uint32{
+	reflect.TypeOf(int64(0)):    pgtype.Int8OID,
+	reflect.TypeOf(float64(0)):  pgtype.Float8OID,
+	reflect.TypeOf(false):       pgtype.BoolOID,
+	reflect.TypeOf(""):          pgtype.TextOID,
+	reflect.TypeOf(time.Time{}): pgtype.TimestamptzOID,
+	reflect.TypeOf([]byte(nil)): pgtype.ByteaOID,
+}
+
+// Close implements the pgx.Rows.Close method.
+func (r *Rows) Close() {
+	_ = r.FakeRows.Close()
+}
+
+// CommandTag implements the pgx.Rows.CommandTag method.
+// It reports the number of rows read, unless the rows were returned for an expectation without result sets.
+func (r *Rows) CommandTag() pgconn.CommandTag {
+	if r.commandTag.String() != "" {
+		return r.commandTag
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("SELECT %d", r.read))
+}
+
+// FieldDescriptions implements the pgx.Rows.FieldDescriptions method.
+func (r *Rows) FieldDescriptions() []pgconn.FieldDescription {
+	return r.fields
+}
+
+// Next implements the pgx.Rows.Next method.
+// Like pgx, it closes the rows automatically when all rows are read.
+func (r *Rows) Next() bool {
+	if r.FakeRows.Next() {
+		r.read++
+		return true
+	}
+	r.Close()
+	return false
+}
+
+// Scan implements the pgx.Rows.Scan method.
+// Like pgx, it skips values for nil destinations.
+func (r *Rows) Scan(dest ...interface{}) error {
+	scans := make([]interface{}, len(dest))
+	for i, d := range dest {
+		if d == nil {
+			d = new(interface{})
+		}
+		scans[i] = d
+	}
+	return r.FakeRows.Scan(scans...)
+}
+
+// RawValues implements the pgx.Rows.RawValues method. It always returns nil.
+func (r *Rows) RawValues() [][]byte {
+	return nil
+}
+
+// Conn implements the pgx.Rows.Conn method. It always returns nil.
+func (r *Rows) Conn() *pgx.Conn {
+	return nil
+}
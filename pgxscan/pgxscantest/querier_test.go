@@ -0,0 +1,84 @@
+package pgxscantest_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+var ctx = context.Background()
+
+type user struct {
+	ID   int
+	Name string
+	Bio  *string
+}
+
+func TestFakeQuerier_Select(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id, name, bio FROM users WHERE name = $1`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id", "name", "bio"}, [][]interface{}{
+			{int64(1), "Bob", nil},
+			{int64(2), "Bob", "Likes Go"},
+		})
+	bio := "Likes Go"
+	expected := []*user{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Bob", Bio: &bio}}
+
+	var got []*user
+	err := pgxscan.Select(ctx, db, &got, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	db.AssertExpectations(t)
+}
+
+func TestFakeQuerier_columnTypedMaps(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := pgxscan.NewDBScanAPI(dbscan.WithColumnTypedMaps(true))
+	require.NoError(t, err)
+	api, err := pgxscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id FROM users`).
+		WillReturnResultSets(dbscantest.ResultSet{
+			Columns:     []string{"id"},
+			Rows:        [][]interface{}{{"1"}},
+			ColumnTypes: []reflect.Type{reflect.TypeOf(int64(0))},
+		})
+
+	var got map[string]interface{}
+	err = api.Get(ctx, db, &got, `SELECT id FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"id": int64(1)}, got)
+}
+
+func TestFakeQuerier_unexpectedQuery_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id FROM users`)
+
+	var ids []int
+	err := pgxscan.Select(ctx, db, &ids, `SELECT name FROM users`)
+
+	expectedErr := "scany: query multiple result rows: dbscantest: unexpected query:\n" +
+		"query: SELECT name FROM users\n" +
+		"args:\n" +
+		"diff with the expected query:\n" +
+		"--- Expected\n" +
+		"+++ Actual\n" +
+		"-query: SELECT id FROM users\n" +
+		"+query: SELECT name FROM users\n" +
+		" args: any"
+	assert.EqualError(t, err, expectedErr)
+}
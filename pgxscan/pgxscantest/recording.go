@@ -0,0 +1,107 @@
+package pgxscantest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+// Execer is something that RecordingQuerier can execute statements that don't return rows with.
+// For example, it can be: *pgxpool.Pool, *pgx.Conn or pgx.Tx.
+type Execer interface {
+	Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+// RecordingQuerier wraps a real pgxscan.Querier and records all queries with their results,
+// so they can be saved as a golden file with Save and replayed later with NewReplayQuerier.
+// Results are read from the real rows entirely and served from memory.
+type RecordingQuerier struct {
+	*dbscantest.Recorder
+	db pgxscan.Querier
+}
+
+var _ pgxscan.Querier = &RecordingQuerier{}
+
+// NewRecordingQuerier returns a new RecordingQuerier that sends queries to db.
+func NewRecordingQuerier(db pgxscan.Querier) *RecordingQuerier {
+	return &RecordingQuerier{Recorder: dbscantest.NewRecorder(), db: db}
+}
+
+// Query implements the pgxscan.Querier.Query method.
+func (rq *RecordingQuerier) Query(ctx context.Context, query string, args ...interface{}) (pgx.Rows, error) {
+	rows, err := rq.db.Query(ctx, query, args...)
+	var set dbscantest.ResultSet
+	if err == nil {
+		set, err = readResultSet(rows)
+	}
+	var sets []dbscantest.ResultSet
+	if err == nil {
+		sets = []dbscantest.ResultSet{set}
+	}
+	if recordErr := rq.RecordQuery(query, args, sets, err); recordErr != nil {
+		return nil, fmt.Errorf("pgxscantest: recording: %w", recordErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewRows(set), nil
+}
+
+// Exec executes a statement that doesn't return rows and records the number of affected rows.
+// It returns an error if the wrapped querier doesn't implement Execer.
+func (rq *RecordingQuerier) Exec(ctx context.Context, query string, args ...interface{}) (pgconn.CommandTag, error) {
+	execer, ok := rq.db.(Execer)
+	if !ok {
+		return pgconn.CommandTag{}, fmt.Errorf("pgxscantest: %T doesn't implement Execer", rq.db)
+	}
+	tag, err := execer.Exec(ctx, query, args...)
+	if recordErr := rq.RecordExec(query, args, tag.RowsAffected(), err); recordErr != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("pgxscantest: recording: %w", recordErr)
+	}
+	return tag, err
+}
+
+func readResultSet(rows pgx.Rows) (dbscantest.ResultSet, error) {
+	defer rows.Close()
+	fds := rows.FieldDescriptions()
+	set := dbscantest.ResultSet{Columns: make([]string, len(fds))}
+	for i, fd := range fds {
+		set.Columns[i] = fd.Name
+	}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return dbscantest.ResultSet{}, err
+		}
+		for i, v := range values {
+			// pgx decodes uuid into a byte array, record it in the text format instead.
+			if uuid, ok := v.([16]byte); ok && fds[i].DataTypeOID == pgtype.UUIDOID {
+				values[i] = fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+			}
+		}
+		set.Rows = append(set.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return dbscantest.ResultSet{}, err
+	}
+	return set, nil
+}
+
+// NewReplayQuerier returns a FakeQuerier that expects the queries recorded in the golden file
+// and serves the recorded results for them.
+// A query with a different text or arguments fails with an error that describes the difference.
+func NewReplayQuerier(path string) (*FakeQuerier, error) {
+	recording, err := dbscantest.LoadRecording(path)
+	if err != nil {
+		return nil, err
+	}
+	fq := NewFakeQuerier()
+	fq.ExpectRecording(recording)
+	return fq, nil
+}
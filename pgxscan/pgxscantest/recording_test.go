@@ -0,0 +1,46 @@
+package pgxscantest_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+func TestRecordingQuerier_replay(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id, name, bio FROM users WHERE name = $1`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id", "name", "bio"}, [][]interface{}{
+			{int64(1), "Bob", nil},
+			{int64(2), "Bob", "Likes Go"},
+		})
+	bio := "Likes Go"
+	expected := []*user{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Bob", Bio: &bio}}
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	recorder := pgxscantest.NewRecordingQuerier(db)
+	var recorded []*user
+	err := pgxscan.Select(ctx, recorder, &recorded, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save(path))
+	assert.Equal(t, expected, recorded)
+
+	replay, err := pgxscantest.NewReplayQuerier(path)
+	require.NoError(t, err)
+	var replayed []*user
+	err = pgxscan.Select(ctx, replay, &replayed, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, expected, replayed)
+	replay.AssertExpectations(t)
+
+	replay, err = pgxscantest.NewReplayQuerier(path)
+	require.NoError(t, err)
+	err = pgxscan.Select(ctx, replay, &replayed, `SELECT id, name, bio FROM users WHERE name = $1`, "Alice")
+	assert.ErrorContains(t, err, "unexpected query")
+}
@@ -0,0 +1,26 @@
+package pgxscan
+
+import "github.com/georgysavva/scany/v2/dbscan"
+
+// WithQueryInErrors makes the API functions that query rows, such as Select and Get,
+// return errors wrapped into *dbscan.QueryError, that contains the query text truncated to maxQueryLen runes
+// and the arguments replaced by the values returned from redact.
+// If redact is nil, all arguments are masked with dbscan.RedactAll.
+// See dbscan.NewQueryError for details.
+func WithQueryInErrors(maxQueryLen int, redact dbscan.RedactFunc) APIOption {
+	return func(api *API) {
+		api.queryErrors = &queryErrorsConfig{maxQueryLen: maxQueryLen, redact: redact}
+	}
+}
+
+type queryErrorsConfig struct {
+	maxQueryLen int
+	redact      dbscan.RedactFunc
+}
+
+func (api *API) queryError(err error, query string, args []interface{}) error {
+	if api.queryErrors == nil {
+		return err
+	}
+	return dbscan.NewQueryError(err, query, args, api.queryErrors.maxQueryLen, api.queryErrors.redact)
+}
@@ -0,0 +1,52 @@
+package pgxscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+func TestWithQueryInErrors(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithQueryInErrors(20, nil))
+	db := pgxscantest.NewFakeQuerier()
+	queryErr := errors.New("syntax error")
+	db.ExpectQuery(`SELECT id FROM users WHERE email = $1`).
+		WithArgs("bob@example.com").
+		WillReturnRows([]string{"id"}, nil)
+	db.ExpectQuery(`SELECT id FROM users WHERE`).WillReturnError(queryErr)
+
+	var id int
+	err := api.Get(ctx, db, &id, `SELECT id FROM users WHERE email = $1`, "bob@example.com")
+	var queryError *dbscan.QueryError
+	require.ErrorAs(t, err, &queryError)
+	assert.Equal(t, "SELECT id FROM users...", queryError.Query)
+	assert.Equal(t, []interface{}{dbscan.RedactedArg}, queryError.Args)
+	assert.True(t, pgxscan.NotFound(err))
+	assert.NotContains(t, err.Error(), "bob@example.com")
+
+	var ids []int
+	err = api.Select(ctx, db, &ids, `SELECT id FROM users WHERE`)
+	require.ErrorAs(t, err, &queryError)
+	assert.Equal(t, "SELECT id FROM users...", queryError.Query)
+	assert.ErrorIs(t, err, queryErr)
+	db.AssertExpectations(t)
+}
+
+func TestWithQueryInErrors_notSet_returnsPlainErr(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnRows([]string{"id"}, nil)
+
+	var id int
+	err := pgxscan.Get(ctx, db, &id, `SELECT id FROM users`)
+	var queryError *dbscan.QueryError
+	assert.False(t, errors.As(err, &queryError))
+	assert.True(t, pgxscan.NotFound(err))
+}
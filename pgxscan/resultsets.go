@@ -0,0 +1,215 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/internal/notfound"
+)
+
+// PgConner is something that pgxscan can get the low-level connection and the type map from.
+// For example, it can be: *pgx.Conn.
+// To use a *pgxpool.Pool, acquire a connection first and pass *pgxpool.Conn.Conn().
+type PgConner interface {
+	PgConn() *pgconn.PgConn
+	TypeMap() *pgtype.Map
+}
+
+var _ PgConner = &pgx.Conn{}
+
+// SelectSets is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectSets for details.
+func SelectSets(ctx context.Context, db PgConner, dsts []interface{}, query string) error {
+	return DefaultAPI.SelectSets(ctx, db, dsts, query)
+}
+
+// ScanAllSets is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSets for details.
+func ScanAllSets(dsts []interface{}, mrr *pgconn.MultiResultReader, typeMap *pgtype.Map) error {
+	return DefaultAPI.ScanAllSets(dsts, mrr, typeMap)
+}
+
+// SelectSetsStrict is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectSetsStrict for details.
+func SelectSetsStrict(ctx context.Context, db PgConner, dsts []interface{}, query string) error {
+	return DefaultAPI.SelectSetsStrict(ctx, db, dsts, query)
+}
+
+// ScanAllSetsStrict is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSetsStrict for details.
+func ScanAllSetsStrict(dsts []interface{}, mrr *pgconn.MultiResultReader, typeMap *pgtype.Map) error {
+	return DefaultAPI.ScanAllSetsStrict(dsts, mrr, typeMap)
+}
+
+// SelectSets is a high-level function that executes a query with multiple statements
+// and calls the ScanAllSets function, so results of each statement are scanned into the corresponding destination.
+// The query is executed via the simple protocol in a single round trip, so it can't have arguments.
+// See ScanAllSets for details.
+func (api *API) SelectSets(ctx context.Context, db PgConner, dsts []interface{}, query string) error {
+	mrr := db.PgConn().Exec(ctx, query)
+	if err := api.ScanAllSets(dsts, mrr, db.TypeMap()); err != nil {
+		return fmt.Errorf("scanning all sets: %w", err)
+	}
+	return nil
+}
+
+// SelectSetsStrict is like SelectSets, but it calls the ScanAllSetsStrict function.
+// See ScanAllSetsStrict for details.
+func (api *API) SelectSetsStrict(ctx context.Context, db PgConner, dsts []interface{}, query string) error {
+	mrr := db.PgConn().Exec(ctx, query)
+	if err := api.ScanAllSetsStrict(dsts, mrr, db.TypeMap()); err != nil {
+		return fmt.Errorf("scanning all sets: %w", err)
+	}
+	return nil
+}
+
+// ScanAllSets is a wrapper around the dbscan.ScanAllSets function.
+// It reads result sets from pgconn.MultiResultReader and decodes values with the type map.
+// After scanning it closes the reader and propagates any errors that could pop up.
+// If a result set scanned with dbscan.One has no rows, it returns an error that wraps pgx.ErrNoRows.
+// See dbscan.ScanAllSets for details.
+func (api *API) ScanAllSets(dsts []interface{}, mrr *pgconn.MultiResultReader, typeMap *pgtype.Map) error {
+	return notfound.Wrap(scanResultSets(api.dbscanAPI.ScanAllSets, dsts, mrr, typeMap), pgx.ErrNoRows)
+}
+
+// ScanAllSetsStrict is a wrapper around the dbscan.ScanAllSetsStrict function.
+// It works like ScanAllSets, but returns an error if the number of result sets
+// doesn't match the number of destinations.
+// See dbscan.ScanAllSetsStrict for details.
+func (api *API) ScanAllSetsStrict(dsts []interface{}, mrr *pgconn.MultiResultReader, typeMap *pgtype.Map) error {
+	return notfound.Wrap(scanResultSets(api.dbscanAPI.ScanAllSetsStrict, dsts, mrr, typeMap), pgx.ErrNoRows)
+}
+
+func scanResultSets(
+	scanFn func(dsts []interface{}, rows dbscan.Rows) error,
+	dsts []interface{}, mrr *pgconn.MultiResultReader, typeMap *pgtype.Map,
+) error {
+	ra := NewResultSetsAdapter(mrr, typeMap)
+	defer ra.Close() //nolint: errcheck
+	if err := scanFn(dsts, ra); err != nil {
+		return err
+	}
+	if err := ra.Close(); err != nil {
+		return fmt.Errorf("scany: close result sets: %w", err)
+	}
+	return nil
+}
+
+// ResultSetsAdapter makes pgconn.MultiResultReader compliant with the dbscan.Rows interface.
+// Unlike RowsAdapter, it supports multiple result sets.
+// Column names are taken from the field descriptions of the current result set,
+// and values are decoded with the provided type map.
+// See dbscan.Rows for details.
+type ResultSetsAdapter struct {
+	mrr     *pgconn.MultiResultReader
+	typeMap *pgtype.Map
+	rr      *pgconn.ResultReader
+	err     error
+	closed  bool
+}
+
+var _ dbscan.Rows = &ResultSetsAdapter{}
+
+// NewResultSetsAdapter returns a new ResultSetsAdapter instance positioned at the first result set.
+func NewResultSetsAdapter(mrr *pgconn.MultiResultReader, typeMap *pgtype.Map) *ResultSetsAdapter {
+	ra := &ResultSetsAdapter{mrr: mrr, typeMap: typeMap}
+	ra.nextResult()
+	return ra
+}
+
+// Columns implements the dbscan.Rows.Columns method.
+func (ra *ResultSetsAdapter) Columns() ([]string, error) {
+	if ra.rr == nil {
+		return nil, fmt.Errorf("scany: no result set available")
+	}
+	fds := ra.rr.FieldDescriptions()
+	columns := make([]string, len(fds))
+	for i, fd := range fds {
+		columns[i] = fd.Name
+	}
+	return columns, nil
+}
+
+// Next implements the dbscan.Rows.Next method.
+func (ra *ResultSetsAdapter) Next() bool {
+	if ra.rr == nil || ra.err != nil {
+		return false
+	}
+	if ra.rr.NextRow() {
+		return true
+	}
+	ra.closeResult()
+	return false
+}
+
+// Scan implements the dbscan.Rows.Scan method.
+func (ra *ResultSetsAdapter) Scan(dest ...interface{}) error {
+	fds := ra.rr.FieldDescriptions()
+	values := ra.rr.Values()
+	if len(dest) != len(values) {
+		return fmt.Errorf("scany: number of field descriptions must equal number of destinations, got %d and %d",
+			len(values), len(dest))
+	}
+	for i, fd := range fds {
+		if err := ra.typeMap.Scan(fd.DataTypeOID, fd.Format, values[i], dest[i]); err != nil {
+			return fmt.Errorf("scany: can't scan into dest[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Err implements the dbscan.Rows.Err method.
+func (ra *ResultSetsAdapter) Err() error {
+	return ra.err
+}
+
+// NextResultSet implements the dbscan.Rows.NextResultSet method.
+// It discards any remaining rows of the current result set.
+func (ra *ResultSetsAdapter) NextResultSet() bool {
+	ra.closeResult()
+	return ra.nextResult()
+}
+
+// Close implements the dbscan.Rows.Close method.
+// It discards all remaining result sets and returns the first error that occurred.
+func (ra *ResultSetsAdapter) Close() error {
+	if ra.closed {
+		return ra.err
+	}
+	ra.closed = true
+	ra.closeResult()
+	ra.rr = nil
+	if err := ra.mrr.Close(); err != nil && ra.err == nil {
+		ra.err = err
+	}
+	return ra.err
+}
+
+func (ra *ResultSetsAdapter) nextResult() bool {
+	ra.rr = nil
+	if ra.closed || ra.err != nil {
+		return false
+	}
+	if !ra.mrr.NextResult() {
+		// The reader is exhausted, close it to find out whether it stopped because of an error.
+		ra.closed = true
+		ra.err = ra.mrr.Close()
+		return false
+	}
+	ra.rr = ra.mrr.ResultReader()
+	return true
+}
+
+func (ra *ResultSetsAdapter) closeResult() {
+	if ra.rr == nil {
+		return
+	}
+	if _, err := ra.rr.Close(); err != nil && ra.err == nil {
+		ra.err = err
+	}
+}
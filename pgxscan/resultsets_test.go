@@ -0,0 +1,145 @@
+package pgxscan_test
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+)
+
+const multipleSetsQuery = `
+	SELECT *
+	FROM (
+		VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2'), ('foo val 3', 'bar val 3')
+	) AS t1 (foo, bar);
+
+	SELECT *
+	FROM (
+		VALUES ('egg val', 'bacon val')
+	) AS t2 (egg, bacon);
+`
+
+type testModel2 struct {
+	Egg   string
+	Bacon string
+}
+
+func acquireConn(t *testing.T) *pgxpool.Conn {
+	t.Helper()
+	conn, err := testDB.Acquire(ctx)
+	require.NoError(t, err)
+	t.Cleanup(conn.Release)
+	return conn
+}
+
+func TestSelectSets(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+	expected1 := []*testModel{
+		{Foo: "foo val", Bar: "bar val"},
+		{Foo: "foo val 2", Bar: "bar val 2"},
+		{Foo: "foo val 3", Bar: "bar val 3"},
+	}
+	expected2 := []*testModel2{
+		{Egg: "egg val", Bacon: "bacon val"},
+	}
+
+	var got1 []*testModel
+	var got2 []*testModel2
+	err := testAPI.SelectSets(ctx, conn.Conn(), []interface{}{&got1, &got2}, multipleSetsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected1, got1)
+	assert.Equal(t, expected2, got2)
+}
+
+func TestSelectSets_mapDestination(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+	expected1 := []map[string]interface{}{{"foo": "foo val", "bar": int64(1)}}
+	expected2 := []string{"baz val"}
+
+	var got1 []map[string]interface{}
+	var got2 []string
+	err := testAPI.SelectSets(ctx, conn.Conn(), []interface{}{&got1, &got2}, `
+		SELECT 'foo val' AS foo, 1::INT8 AS bar;
+		SELECT 'baz val' AS baz;
+	`)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected1, got1)
+	assert.Equal(t, expected2, got2)
+}
+
+func TestSelectSets_statementError_returnsErr(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+
+	var got1 []*testModel
+	var got2 []*testModel
+	err := testAPI.SelectSets(ctx, conn.Conn(), []interface{}{&got1, &got2}, `
+		SELECT 'foo val' AS foo, 'bar val' AS bar;
+		SELECT foo FROM not_existing_table;
+	`)
+
+	assert.ErrorContains(t, err, "not_existing_table")
+}
+
+func TestSelectSetsStrict(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+	expected2 := testModel2{Egg: "egg val", Bacon: "bacon val"}
+
+	var got2 testModel2
+	err := testAPI.SelectSetsStrict(ctx, conn.Conn(), []interface{}{nil, dbscan.One(&got2)}, multipleSetsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected2, got2)
+}
+
+func TestSelectSetsStrict_fewerResultSets_returnsErr(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+
+	var got1 []*testModel
+	var got2 []*testModel2
+	var got3 []*testModel2
+	err := testAPI.SelectSetsStrict(ctx, conn.Conn(), []interface{}{&got1, &got2, &got3}, multipleSetsQuery)
+
+	assert.EqualError(t, err, "scanning all sets: scany: expected 3 result sets, got: 2")
+}
+
+func TestSelectSets_oneDestinationNoRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+
+	var got1 []*testModel
+	var got2 testModel
+	err := testAPI.SelectSets(ctx, conn.Conn(), []interface{}{&got1, dbscan.One(&got2)}, `
+		SELECT 'foo val' AS foo, 'bar val' AS bar;
+		SELECT 'foo val' AS foo, 'bar val' AS bar WHERE false;
+	`)
+
+	assert.True(t, pgxscan.NotFound(err))
+	assert.True(t, dbscan.NotFound(err))
+}
+
+func TestSelectSetsStrict_oneDestinationNoRows_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	conn := acquireConn(t)
+
+	var got1 []*testModel
+	var got2 testModel
+	err := testAPI.SelectSetsStrict(ctx, conn.Conn(), []interface{}{&got1, dbscan.One(&got2)}, `
+		SELECT 'foo val' AS foo, 'bar val' AS bar;
+		SELECT 'foo val' AS foo, 'bar val' AS bar WHERE false;
+	`)
+
+	assert.True(t, pgxscan.NotFound(err))
+	assert.True(t, dbscan.NotFound(err))
+	assert.EqualError(t, err, "scanning all sets: error processing destination 1: scany: no row was found")
+}
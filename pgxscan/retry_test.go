@@ -0,0 +1,105 @@
+package pgxscan_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+// fastRetries is a retry policy that doesn't slow tests down.
+var fastRetries = dbscan.RetryPolicy{
+	MaxAttempts: 3,
+	Backoff:     func(int) time.Duration { return time.Millisecond },
+}
+
+func TestWithRetryPolicy_retriesTransientErr(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithRetryPolicy(fastRetries))
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnError(&pgconn.PgError{Code: "40001"})
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}})
+
+	var ids []int
+	err := api.Select(ctx, db, &ids, `SELECT id FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2}, ids)
+	db.AssertExpectations(t)
+}
+
+func TestWithRetryPolicy_nonTransientErr_returnsErr(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithRetryPolicy(fastRetries))
+	db := pgxscantest.NewFakeQuerier()
+	uniqueViolation := &pgconn.PgError{Code: "23505"}
+	db.ExpectQuery(`INSERT INTO users (name) VALUES ($1) RETURNING id`).
+		WithArgs("Bob").
+		WillReturnError(uniqueViolation)
+
+	var id int
+	err := api.Get(ctx, db, &id, `INSERT INTO users (name) VALUES ($1) RETURNING id`, "Bob")
+
+	assert.ErrorIs(t, err, uniqueViolation)
+	db.AssertExpectations(t)
+}
+
+func TestWithRetryPolicy_errAfterRowsRead_doesNotRetry(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithRetryPolicy(fastRetries))
+	db := pgxscantest.NewFakeQuerier()
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+	db.ExpectQuery(`SELECT id FROM users`).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}}).
+		WillReturnRowsErr(1, serializationFailure)
+
+	var ids []int
+	err := api.Select(ctx, db, &ids, `SELECT id FROM users`)
+
+	assert.ErrorIs(t, err, serializationFailure)
+	db.AssertExpectations(t)
+}
+
+func TestWithRetryPolicy_transientErrOnEveryAttempt_returnsErr(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithRetryPolicy(fastRetries))
+	db := pgxscantest.NewFakeQuerier()
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnError(serializationFailure).Times(fastRetries.MaxAttempts)
+
+	var ids []int
+	err := api.Select(ctx, db, &ids, `SELECT id FROM users`)
+
+	assert.ErrorIs(t, err, serializationFailure)
+	db.AssertExpectations(t)
+}
+
+func TestWithRetryPolicy_insideRetryableTx_retriesTx(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, pgxscan.WithRetryPolicy(fastRetries))
+	db := pgxscantest.NewFakeQuerier()
+	db.ExpectQuery(`SAVEPOINT cockroach_restart`)
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnError(&pgconn.PgError{Code: "40001"})
+	db.ExpectQuery(`ROLLBACK TO SAVEPOINT cockroach_restart`)
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}})
+	db.ExpectQuery(`RELEASE SAVEPOINT cockroach_restart`)
+
+	var calls int
+	var ids []int
+	err := pgxscan.WithRetryableTx(ctx, fakeTxBeginner{db}, func(tx pgx.Tx) error {
+		calls++
+		return api.Select(ctx, tx, &ids, `SELECT id FROM users`)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []int{1}, ids)
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,73 @@
+package pgxscan
+
+import (
+	"context"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// WithQueryTracer sets a tracer that is notified about every query sent by Select, Get and ExecReturning,
+// and by the functions built on top of them, e.g. SelectNamed.
+// See dbscan.QueryTracer for details.
+func WithQueryTracer(tracer dbscan.QueryTracer) APIOption {
+	return func(api *API) {
+		api.tracer = tracer
+	}
+}
+
+type queryTrace struct {
+	tracer dbscan.QueryTracer
+	ctx    context.Context
+	data   dbscan.QueryEndData
+	start  time.Time
+}
+
+// startTrace returns a nil *queryTrace if the API has no tracer, all queryTrace methods handle that.
+func (api *API) startTrace(ctx context.Context, method, query string, args []interface{}) (context.Context, *queryTrace) {
+	if api.tracer == nil {
+		return ctx, nil
+	}
+	data := dbscan.QueryStartData{Method: method, Query: query, Args: args}
+	ctx = api.tracer.QueryStart(ctx, data)
+	qt := &queryTrace{
+		tracer: api.tracer,
+		ctx:    ctx,
+		data:   dbscan.QueryEndData{QueryStartData: data},
+		start:  time.Now(),
+	}
+	return ctx, qt
+}
+
+func (qt *queryTrace) queried() {
+	if qt == nil {
+		return
+	}
+	qt.data.QueryDuration = time.Since(qt.start)
+}
+
+func (qt *queryTrace) end(rows *countingRows, err error) {
+	if qt == nil {
+		return
+	}
+	if rows != nil {
+		qt.data.Rows = rows.count
+		qt.data.ScanDuration = time.Since(qt.start) - qt.data.QueryDuration
+	}
+	qt.data.Err = err
+	qt.tracer.QueryEnd(qt.ctx, qt.data)
+}
+
+// countingRows counts rows read by dbscan, it embeds *RowsAdapter to keep its optional interfaces.
+type countingRows struct {
+	*RowsAdapter
+	count int
+}
+
+func (cr *countingRows) Next() bool {
+	if !cr.RowsAdapter.Next() {
+		return false
+	}
+	cr.count++
+	return true
+}
@@ -0,0 +1,90 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/georgysavva/scany/v2/internal/crdbtx"
+)
+
+// TxBeginner is something that pgxscan can begin a transaction on.
+// For example, it can be: *pgxpool.Pool, *pgx.Conn or pgx.Tx, the latter starts a nested transaction.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+var (
+	_ TxBeginner = &pgxpool.Pool{}
+	_ TxBeginner = &pgx.Conn{}
+	_ TxBeginner = pgx.Tx(nil)
+)
+
+// WithTx begins a transaction and calls fn with it.
+// If fn returns an error or panics, the transaction is rolled back,
+// otherwise it's committed. The error returned by fn is returned as is, or wrapped if the rollback fails too.
+func WithTx(ctx context.Context, db TxBeginner, fn func(tx pgx.Tx) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			return fmt.Errorf("scany: rollback transaction: %v: %w", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("scany: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithRetryableTx works like WithTx, but it retries fn when the transaction fails
+// with a serialization failure (SQLSTATE 40001), the same way as crdb.ExecuteTx
+// from github.com/cockroachdb/cockroach-go/v2/crdb does it:
+// fn runs after "SAVEPOINT cockroach_restart", the transaction is rolled back to the savepoint
+// and fn is called again on a retryable error, up to 50 retries.
+// fn can be called multiple times, so it must not have side effects outside of the transaction,
+// and it must return the errors of pgx, possibly wrapped, for them to be retried.
+// An error during the final RELEASE SAVEPOINT is returned as *crdb.AmbiguousCommitError.
+// The savepoint protocol is meant for CockroachDB, db must not be a pgx.Tx, otherwise an error is returned.
+func WithRetryableTx(ctx context.Context, db TxBeginner, fn func(tx pgx.Tx) error) error {
+	if _, ok := db.(pgx.Tx); ok {
+		return errors.New("scany: can't run a retryable transaction inside pgx.Tx")
+	}
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	return crdbtx.ExecuteInTx(ctx, pgxTxAdapter{tx: tx}, func() error { return fn(tx) })
+}
+
+type pgxTxAdapter struct {
+	tx pgx.Tx
+}
+
+var _ crdb.Tx = pgxTxAdapter{}
+
+func (ta pgxTxAdapter) Exec(ctx context.Context, query string, args ...interface{}) error {
+	_, err := ta.tx.Exec(ctx, query, args...)
+	return err
+}
+
+func (ta pgxTxAdapter) Commit(ctx context.Context) error {
+	return ta.tx.Commit(ctx)
+}
+
+func (ta pgxTxAdapter) Rollback(ctx context.Context) error {
+	return ta.tx.Rollback(ctx)
+}
@@ -0,0 +1,149 @@
+package pgxscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/georgysavva/scany/v2/pgxscan/pgxscantest"
+)
+
+func countRows(t *testing.T, table string) int {
+	t.Helper()
+	var count int
+	err := pgxscan.Get(ctx, testDB, &count, `SELECT count(*) FROM `+table)
+	require.NoError(t, err)
+	return count
+}
+
+func TestWithTx(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE with_tx (foo TEXT)`)
+	require.NoError(t, err)
+
+	err = pgxscan.WithTx(ctx, testDB, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO with_tx (foo) VALUES ('foo val')`)
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, countRows(t, "with_tx"))
+}
+
+func TestWithTx_fnReturnsErr_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE with_tx_error (foo TEXT)`)
+	require.NoError(t, err)
+	fnErr := errors.New("some error")
+
+	err = pgxscan.WithTx(ctx, testDB, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `INSERT INTO with_tx_error (foo) VALUES ('foo val')`)
+		require.NoError(t, err)
+		return fnErr
+	})
+
+	assert.Equal(t, fnErr, err)
+	assert.Equal(t, 0, countRows(t, "with_tx_error"))
+}
+
+func TestWithTx_fnPanics_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE with_tx_panic (foo TEXT)`)
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "some panic", func() {
+		_ = pgxscan.WithTx(ctx, testDB, func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `INSERT INTO with_tx_panic (foo) VALUES ('foo val')`)
+			require.NoError(t, err)
+			panic("some panic")
+		})
+	})
+
+	assert.Equal(t, 0, countRows(t, "with_tx_panic"))
+}
+
+func TestWithRetryableTx_retriesSerializationFailure(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE with_retryable_tx (foo TEXT)`)
+	require.NoError(t, err)
+	calls := 0
+
+	err = pgxscan.WithRetryableTx(ctx, testDB, func(tx pgx.Tx) error {
+		calls++
+		_, err := tx.Exec(ctx, `INSERT INTO with_retryable_tx (foo) VALUES ('foo val')`)
+		require.NoError(t, err)
+		if calls == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, countRows(t, "with_retryable_tx"))
+}
+
+func TestWithRetryableTx_fnPanics_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.Exec(ctx, `CREATE TABLE with_retryable_tx_panic (foo TEXT)`)
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "some panic", func() {
+		_ = pgxscan.WithRetryableTx(ctx, testDB, func(tx pgx.Tx) error {
+			_, err := tx.Exec(ctx, `INSERT INTO with_retryable_tx_panic (foo) VALUES ('foo val')`)
+			require.NoError(t, err)
+			panic("some panic")
+		})
+	})
+
+	assert.Equal(t, 0, countRows(t, "with_retryable_tx_panic"))
+}
+
+// fakeTxBeginner begins fake transactions that send all queries to the FakeQuerier.
+type fakeTxBeginner struct {
+	*pgxscantest.FakeQuerier
+}
+
+func (b fakeTxBeginner) Begin(context.Context) (pgx.Tx, error) {
+	return fakeTx(b), nil
+}
+
+// fakeTx implements the methods of pgx.Tx used by scany, the rest of them panic.
+type fakeTx struct {
+	*pgxscantest.FakeQuerier
+}
+
+var _ pgx.Tx = fakeTx{}
+
+func (fakeTx) Begin(context.Context) (pgx.Tx, error) { panic("not implemented") }
+func (fakeTx) Commit(context.Context) error          { return nil }
+func (fakeTx) Rollback(context.Context) error        { return nil }
+func (fakeTx) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	panic("not implemented")
+}
+func (fakeTx) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults { panic("not implemented") }
+func (fakeTx) LargeObjects() pgx.LargeObjects                         { panic("not implemented") }
+func (fakeTx) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
+	panic("not implemented")
+}
+func (fakeTx) QueryRow(context.Context, string, ...interface{}) pgx.Row { panic("not implemented") }
+func (fakeTx) Conn() *pgx.Conn                                          { return nil }
+
+func TestWithRetryableTx_insideTx_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := pgxscantest.NewFakeQuerier()
+
+	err := pgxscan.WithRetryableTx(ctx, fakeTx{db}, func(tx pgx.Tx) error {
+		t.Fatal("fn must not be called")
+		return nil
+	})
+
+	assert.EqualError(t, err, "scany: can't run a retryable transaction inside pgx.Tx")
+	db.AssertExpectations(t)
+}
@@ -0,0 +1,38 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// SelectColumns is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectColumns for details.
+func SelectColumns(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.SelectColumns(ctx, db, dst, query, args...)
+}
+
+// ScanColumns is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanColumns for details.
+func ScanColumns(dst interface{}, rows *sql.Rows) error {
+	return DefaultAPI.ScanColumns(dst, rows)
+}
+
+// SelectColumns is a high-level function that queries rows from Querier and calls the ScanColumns function.
+// See ScanColumns for details.
+func (api *API) SelectColumns(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
+	rows, err := api.queryFn(ctx, db, query, args...)
+	if err != nil {
+		return api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+	}
+	if err := api.ScanColumns(dst, rows); err != nil {
+		return api.queryError(fmt.Errorf("scanning columns: %w", err), query, args)
+	}
+	return nil
+}
+
+// ScanColumns is a wrapper around the dbscan.ScanColumns function.
+// See dbscan.ScanColumns for details.
+func (api *API) ScanColumns(dst interface{}, rows *sql.Rows) error {
+	return api.dbscanAPI.ScanColumns(dst, NewRowsAdapter(rows))
+}
@@ -0,0 +1,26 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectColumns(t *testing.T) {
+	t.Parallel()
+	type columns struct {
+		Foos []string `db:"foo"`
+		Bars []string `db:"bar"`
+	}
+	expected := &columns{
+		Foos: []string{"foo val", "foo val 2", "foo val 3"},
+		Bars: []string{"bar val", "bar val 2", "bar val 3"},
+	}
+
+	got := &columns{}
+	err := testAPI.SelectColumns(ctx, testDB, got, multipleRowsQuery)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
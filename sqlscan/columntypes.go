@@ -0,0 +1,114 @@
+package sqlscan
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+var (
+	stringType  = reflect.TypeOf("")
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	boolType    = reflect.TypeOf(false)
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// RowsAdapter makes *sql.Rows implement the dbscan.ColumnScanTyper interface,
+// so dbscan can pick Go types for map values based on the column types,
+// see dbscan.WithColumnTypedMaps for details.
+// sqlscan wraps *sql.Rows with RowsAdapter automatically.
+type RowsAdapter struct {
+	*sql.Rows
+}
+
+var _ dbscan.ColumnScanTyper = NewRowsAdapter(nil)
+
+// NewRowsAdapter returns a new RowsAdapter instance.
+func NewRowsAdapter(rows *sql.Rows) *RowsAdapter {
+	return &RowsAdapter{Rows: rows}
+}
+
+// ColumnScanTypes implements the dbscan.ColumnScanTyper.ColumnScanTypes method.
+// It maps each column to one of string, int64, float64, bool or time.Time
+// based on the scan type and the database type name reported by the driver.
+// Columns that don't fit any of those, e.g. binary ones, get a nil type.
+// time.Time is only chosen if the driver reports time.Time as the scan type itself.
+// Date and time columns reported as sql.NullTime or only by the database type name get a nil type,
+// because the driver might return them as []byte, e.g. MySQL driver without the parseTime option.
+func (ra *RowsAdapter) ColumnScanTypes() ([]reflect.Type, error) {
+	columnTypes, err := ra.Rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("scany: get column types: %w", err)
+	}
+	scanTypes := make([]reflect.Type, len(columnTypes))
+	for i, ct := range columnTypes {
+		scanTypes[i] = columnScanType(ct.ScanType(), ct.DatabaseTypeName())
+	}
+	return scanTypes, nil
+}
+
+func columnScanType(scanType reflect.Type, databaseTypeName string) reflect.Type {
+	// Drivers that report a precise scan type know best how to convert the value.
+	if scanType != nil {
+		for scanType.Kind() == reflect.Ptr {
+			scanType = scanType.Elem()
+		}
+		if scanType.ConvertibleTo(timeType) {
+			return timeType
+		}
+		switch scanType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			return int64Type
+		case reflect.Float32, reflect.Float64:
+			return float64Type
+		case reflect.Bool:
+			return boolType
+		case reflect.String:
+			return stringType
+		}
+		if t, ok := nullScanTypes[scanType]; ok {
+			return t
+		}
+	}
+	// Otherwise, e.g. for sql.RawBytes or interface{}, fall back to the database type name.
+	return databaseTypeScanType(databaseTypeName)
+}
+
+var nullScanTypes = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(sql.NullString{}):  stringType,
+	reflect.TypeOf(sql.NullInt64{}):   int64Type,
+	reflect.TypeOf(sql.NullInt32{}):   int64Type,
+	reflect.TypeOf(sql.NullInt16{}):   int64Type,
+	reflect.TypeOf(sql.NullByte{}):    int64Type,
+	reflect.TypeOf(sql.NullFloat64{}): float64Type,
+	reflect.TypeOf(sql.NullBool{}):    boolType,
+}
+
+func databaseTypeScanType(databaseTypeName string) reflect.Type {
+	name := strings.ToUpper(databaseTypeName)
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimSpace(strings.TrimPrefix(name, "UNSIGNED "))
+	switch name {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT",
+		"INT2", "INT4", "INT8", "SERIAL", "BIGSERIAL", "YEAR":
+		return int64Type
+	case "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE", "DOUBLE PRECISION", "REAL":
+		return float64Type
+	case "BOOL", "BOOLEAN":
+		return boolType
+	case "CHAR", "VARCHAR", "NCHAR", "NVARCHAR", "TEXT", "NTEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT",
+		"CLOB", "CHARACTER", "CHARACTER VARYING", "BPCHAR", "NAME", "UUID", "UNIQUEIDENTIFIER",
+		"DECIMAL", "NUMERIC", "MONEY", "ENUM", "SET", "JSON", "JSONB", "XML", "TIME":
+		return stringType
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,46 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestColumnTypedMaps_scanTypes(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI(dbscan.WithColumnTypedMaps(true))
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI)
+	require.NoError(t, err)
+	db := newFakeQuerier(t)
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	db.ExpectQuery(`SELECT id, name, updated_at, created_at FROM users`).WillReturnResultSets(dbscantest.ResultSet{
+		Columns: []string{"id", "name", "updated_at", "created_at"},
+		Rows: [][]interface{}{
+			{int32(1), "Bob", []byte("2020-01-02 03:04:05"), createdAt},
+		},
+		ColumnTypes: []reflect.Type{
+			reflect.TypeOf(int32(0)), reflect.TypeOf(sql.NullString{}), reflect.TypeOf(sql.NullTime{}), reflect.TypeOf(time.Time{}),
+		},
+	})
+
+	var results []map[string]interface{}
+	err = api.Select(ctx, db, &results, `SELECT id, name, updated_at, created_at FROM users`)
+	require.NoError(t, err)
+
+	expected := []map[string]interface{}{{
+		"id":         int64(1),
+		"name":       "Bob",
+		"updated_at": []byte("2020-01-02 03:04:05"),
+		"created_at": createdAt,
+	}}
+	assert.Equal(t, expected, results)
+}
@@ -14,5 +14,145 @@ sqlscan can query rows and work with *sql.DB, *sql.Conn or *sql.Tx directly.
 To support this it has two high-level functions Select and Get,
 they accept anything that implements Querier interface and query rows from it.
 This means that they can be used with *sql.DB, *sql.Conn or *sql.Tx.
+
+Named parameters
+
+Most database/sql drivers don't support named parameters.
+NamedSelect, NamedGet and NamedExec functions accept queries with ":name" parameters
+and bind them from a struct or a map[string]interface{} before passing the query to the driver:
+
+	type Filter struct {
+		Email string
+		Age   int
+	}
+
+	var users []*User
+	sqlscan.NamedSelect(ctx, db, &users, `SELECT * FROM users WHERE email = :email AND age > :age`, &Filter{...})
+
+Struct fields are matched to parameter names with the same rules that dbscan uses to match columns.
+Parameters are rewritten to the positional placeholders of the driver, "$1" by default,
+use WithPlaceholder to change the format, see BindNamed for details.
+
+Modifying rows
+
+ExecReturning function executes a query that modifies rows, scans rows returned by its RETURNING clause
+into a slice of structs or maps, or a single destination, and returns the number of affected rows.
+ExecExpect function returns an error that wraps dbscan.ErrUnexpectedRowsAffected
+if the number of affected rows differs from the expected one:
+
+	var user User
+	n, err := sqlscan.ExecReturning(ctx, db, &user, `INSERT INTO users (name) VALUES ($1) RETURNING *`, "Bob")
+
+	err = sqlscan.ExecExpect(ctx, db, 1, nil, `UPDATE users SET name = $1 WHERE id = $2`, "Bob", userID)
+
+database/sql doesn't report affected rows for queries that return rows, so ExecReturning counts returned rows,
+and a nil destination executes the query via Execer instead.
+
+Column typed maps
+
+Drivers return different types for the same column when scanning into map[string]interface{},
+e.g. []byte for text columns in MySQL. To get the same map values regardless of the driver,
+enable dbscan.WithColumnTypedMaps option, sqlscan then chooses a type for each column from (*sql.Rows).ColumnTypes():
+
+	dbscanAPI, err := sqlscan.NewDBScanAPI(dbscan.WithColumnTypedMaps(true))
+	api, err := sqlscan.NewAPI(dbscanAPI)
+
+	var results []map[string]interface{}
+	api.Select(ctx, db, &results, `SELECT id, name, created_at FROM users`)
+	// results values are int64, string and time.Time, or nil for NULLs.
+
+See RowsAdapter.ColumnScanTypes for details.
+
+Tracing
+
+WithQueryTracer option sets a dbscan.QueryTracer that is called around every query sent by Select, Get and ExecReturning.
+It receives the query text and arguments, the number of rows read, query and scan durations and the error, if any:
+
+	tracer := dbscan.QueryHooks{
+		OnQueryEnd: func(ctx context.Context, data dbscan.QueryEndData) {
+			queryDuration.WithLabelValues(data.Method).Observe(data.QueryDuration.Seconds())
+		},
+	}
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithQueryTracer(tracer))
+
+Interceptors
+
+WithInterceptors option wraps the call that sends a query to the Querier in Select, Get and other functions that query rows.
+An interceptor can change the query, e.g. add a comment with request metadata, send it to a different Querier, such as a read replica,
+or reject it without calling the next function:
+
+	addComment := func(next sqlscan.QueryFunc) sqlscan.QueryFunc {
+		return func(ctx context.Context, db sqlscan.Querier, query string, args ...interface{}) (*sql.Rows, error) {
+			return next(ctx, db, query+" -- service: users", args...)
+		}
+	}
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithInterceptors(addComment))
+
+Rows are scanned after the interceptor returns,
+so an interceptor must not cancel the context it passes to the next function, e.g. to apply a timeout.
+
+Query in errors
+
+WithQueryInErrors option wraps errors returned by Select, Get and other functions that query rows into *dbscan.QueryError,
+that contains the query text truncated to the given length and redacted arguments. Use errors.As to access it:
+
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithQueryInErrors(200, dbscan.RedactAll))
+
+	var queryErr *dbscan.QueryError
+	if errors.As(err, &queryErr) {
+		log.Printf("query %q failed: %v", queryErr.Query, queryErr.Err)
+	}
+
+Arguments are masked by default, pass a custom dbscan.RedactFunc to only mask sensitive arguments.
+
+Retries
+
+WithRetryPolicy option makes Select and Get retry queries that fail with a transient error before any row is read,
+such as a serialization failure (SQLSTATE 40001) in CockroachDB or a lost connection:
+
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithRetryPolicy(dbscan.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff:     dbscan.ExponentialBackoff(10*time.Millisecond, time.Second),
+	}))
+
+Errors are classified by dbscan.IsRetryable unless a custom classifier is set.
+Retries stop when the context is done or its deadline comes before the next attempt.
+Queries are sent again as is, so only use retries with queries that are safe to repeat.
+Queries made on a *sql.Tx aren't retried, since the transaction is aborted after an error,
+use WithRetryableTx to retry the whole transaction instead.
+
+Transactions
+
+WithTx function runs a function in a transaction, it commits the transaction if the function succeeds
+and rolls it back if the function returns an error or panics:
+
+	err := sqlscan.WithTx(ctx, db, nil, func(tx *sql.Tx) error {
+		if err := sqlscan.Get(ctx, tx, &user, `SELECT * FROM users WHERE id = $1 FOR UPDATE`, userID); err != nil {
+			return err
+		}
+		return sqlscan.Select(ctx, tx, &posts, `SELECT * FROM posts WHERE user_id = $1`, userID)
+	})
+
+WithRetryableTx function also retries the function on serialization failures,
+with the same semantics as crdb.ExecuteTx from github.com/cockroachdb/cockroach-go/v2/crdb.
+
+Prepared statements
+
+WithStatementCache option makes Select, Get, ExecReturning and the functions built on top of them use prepared statements,
+kept in a least recently used cache of the given size per query text and per *sql.DB or *sql.Conn:
+
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithStatementCache(100))
+
+Queries on StmtTx, a transaction paired with the *sql.DB it was started on, use the cached statements via Tx.StmtContext.
+Queries on a bare *sql.Tx aren't prepared, since database/sql doesn't tell which *sql.DB it belongs to,
+pass StmtTx to use the cache in a transaction.
+A statement that fails because its plan is stale, e.g. after a schema change, is evicted and prepared again.
+API.CloseStatements closes the cached statements.
+
+Testing
+
+Package sqlscantest provides FakeQuerier that returns canned rows for expected queries,
+so code that uses sqlscan can be unit tested without a database,
+see https://pkg.go.dev/github.com/georgysavva/scany/v2/sqlscan/sqlscantest for details.
 */
 package sqlscan
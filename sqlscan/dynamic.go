@@ -0,0 +1,43 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// SelectDynamic is a package-level helper function that uses the DefaultAPI object.
+// See API.SelectDynamic for details.
+func SelectDynamic(ctx context.Context, db Querier, query string, args ...interface{}) ([]interface{}, reflect.Type, error) {
+	return DefaultAPI.SelectDynamic(ctx, db, query, args...)
+}
+
+// ScanAllDynamic is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllDynamic for details.
+func ScanAllDynamic(rows *sql.Rows) ([]interface{}, reflect.Type, error) {
+	return DefaultAPI.ScanAllDynamic(rows)
+}
+
+// SelectDynamic is a high-level function that queries rows from Querier and calls the ScanAllDynamic function.
+// See ScanAllDynamic for details.
+func (api *API) SelectDynamic(
+	ctx context.Context, db Querier, query string, args ...interface{},
+) ([]interface{}, reflect.Type, error) {
+	rows, err := api.queryFn(ctx, db, query, args...)
+	if err != nil {
+		return nil, nil, api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+	}
+	values, structType, err := api.ScanAllDynamic(rows)
+	if err != nil {
+		return nil, nil, api.queryError(fmt.Errorf("scanning all dynamic: %w", err), query, args)
+	}
+	return values, structType, nil
+}
+
+// ScanAllDynamic is a wrapper around the dbscan.ScanAllDynamic function.
+// Field types are chosen from the column types, see RowsAdapter.ColumnScanTypes for details.
+// See dbscan.ScanAllDynamic for details.
+func (api *API) ScanAllDynamic(rows *sql.Rows) ([]interface{}, reflect.Type, error) {
+	return api.dbscanAPI.ScanAllDynamic(NewRowsAdapter(rows))
+}
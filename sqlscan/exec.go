@@ -0,0 +1,108 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// ExecReturning is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecReturning for details.
+func ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) (int64, error) {
+	return DefaultAPI.ExecReturning(ctx, db, dst, query, args...)
+}
+
+// ExecExpect is a package-level helper function that uses the DefaultAPI object.
+// See API.ExecExpect for details.
+func ExecExpect(ctx context.Context, db Querier, n int64, dst interface{}, query string, args ...interface{}) error {
+	return DefaultAPI.ExecExpect(ctx, db, n, dst, query, args...)
+}
+
+// ExecReturning executes a query that modifies rows, such as INSERT, UPDATE or DELETE,
+// scans the rows returned by its RETURNING clause into dst and returns the number of affected rows.
+// If dst is a pointer to a slice of structs or maps, all returned rows are scanned the same way as ScanAll does it,
+// otherwise exactly one row is expected and scanned the same way as ScanOne does it, see dbscan.IsRowsSlice.
+// So a pointer to a slice of primitive types, e.g. *[]byte or *[]string, receives a single column value,
+// to get a column of several rows, use a slice of structs.
+// database/sql doesn't report affected rows for queries that return rows,
+// so the number of affected rows is the number of returned rows.
+// If dst is nil, the query is executed via the ExecContext method, so db must implement Execer,
+// and the number of affected rows is taken from sql.Result. Interceptors set with WithInterceptors
+// aren't applied in that case, since they wrap queries that return rows,
+// the tracer and the statement cache are used as usual.
+func (api *API) ExecReturning(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) (int64, error) {
+	if dst == nil {
+		return api.exec(ctx, db, query, args)
+	}
+	ctx, trace := api.startTrace(ctx, "ExecReturning", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scany: query returning rows: %w", err), query, args)
+		trace.end(nil, err)
+		return 0, err
+	}
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if api.dbscanAPI.IsRowsSlice(dst) {
+		err = api.scanAll(dst, cr)
+	} else {
+		err = api.scanOne(dst, cr)
+	}
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scanning returning rows: %w", err), query, args)
+		trace.end(cr, err)
+		return int64(cr.count), err
+	}
+	trace.end(cr, nil)
+	return int64(cr.count), nil
+}
+
+// exec executes the query without a destination for ExecReturning.
+// Interceptors wrap QueryFunc that returns rows, so they aren't applied here.
+func (api *API) exec(ctx context.Context, db Querier, query string, args []interface{}) (int64, error) {
+	execer, ok := db.(Execer)
+	if !ok {
+		return 0, fmt.Errorf("scany: %T must implement Execer to execute a query without a destination", db)
+	}
+	ctx, trace := api.startTrace(ctx, "ExecReturning", query, args)
+	var res sql.Result
+	var err error
+	if api.stmtCache != nil {
+		res, err = api.stmtCache.exec(ctx, db, execer, query, args...)
+	} else {
+		res, err = execer.ExecContext(ctx, query, args...)
+	}
+	trace.queried()
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scany: exec query: %w", err), query, args)
+		trace.end(nil, err)
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		err = api.queryError(fmt.Errorf("scany: get affected rows: %w", err), query, args)
+		trace.end(nil, err)
+		return 0, err
+	}
+	trace.end(nil, nil)
+	return n, nil
+}
+
+// ExecExpect works like ExecReturning, but instead of returning the number of affected rows
+// it makes sure that the query affected exactly n rows, otherwise it returns an error
+// that wraps dbscan.ErrUnexpectedRowsAffected. The changes aren't reverted in that case,
+// run the query in a transaction, e.g. with WithTx, to roll them back.
+func (api *API) ExecExpect(ctx context.Context, db Querier, n int64, dst interface{}, query string, args ...interface{}) error {
+	affected, err := api.ExecReturning(ctx, db, dst, query, args...)
+	if err != nil {
+		return err
+	}
+	if affected != n {
+		return api.queryError(
+			fmt.Errorf("%w: expected %d, got: %d", dbscan.ErrUnexpectedRowsAffected, n, affected), query, args,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+type returnedUser struct {
+	ID   int
+	Name string
+}
+
+func TestExecReturning(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`INSERT INTO users (name) VALUES ($1), ($2) RETURNING id, name`).
+		WithArgs("Bob", "Alice").
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bob"}, {int64(2), "Alice"}})
+	db.ExpectQuery(`UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`).
+		WithArgs("Bobby", 1).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bobby"}})
+	db.ExpectQuery(`DELETE FROM users`).WillReturnRowsAffected(2)
+
+	var inserted []*returnedUser
+	n, err := sqlscan.ExecReturning(ctx, db, &inserted,
+		`INSERT INTO users (name) VALUES ($1), ($2) RETURNING id, name`, "Bob", "Alice")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	assert.Equal(t, []*returnedUser{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Alice"}}, inserted)
+
+	var updated returnedUser
+	n, err = sqlscan.ExecReturning(ctx, db, &updated, `UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`, "Bobby", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, returnedUser{ID: 1, Name: "Bobby"}, updated)
+
+	n, err = sqlscan.ExecReturning(ctx, db, nil, `DELETE FROM users`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	db.AssertExpectations(t)
+}
+
+func TestExecReturning_noRowsForStruct_returnsNotFoundErr(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`).
+		WithArgs("Bobby", 3).
+		WillReturnRows([]string{"id", "name"}, nil)
+
+	var updated returnedUser
+	n, err := sqlscan.ExecReturning(ctx, db, &updated, `UPDATE users SET name = $1 WHERE id = $2 RETURNING id, name`, "Bobby", 3)
+
+	assert.True(t, sqlscan.NotFound(err))
+	assert.Equal(t, int64(0), n)
+}
+
+func TestExecExpect(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`UPDATE users SET bio = NULL`).WillReturnRowsAffected(3).Times(2)
+
+	err := sqlscan.ExecExpect(ctx, db, 3, nil, `UPDATE users SET bio = NULL`)
+	require.NoError(t, err)
+
+	err = sqlscan.ExecExpect(ctx, db, 1, nil, `UPDATE users SET bio = NULL`)
+	assert.ErrorIs(t, err, dbscan.ErrUnexpectedRowsAffected)
+	assert.EqualError(t, err, "scany: unexpected number of affected rows: expected 1, got: 3")
+	db.AssertExpectations(t)
+}
+
+func TestExecReturning_byteSliceDst_scansOneRow(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`UPDATE users SET avatar = $1 WHERE id = $2 RETURNING avatar`).
+		WillReturnRows([]string{"avatar"}, [][]interface{}{{[]byte("png")}})
+
+	var avatar []byte
+	n, err := sqlscan.ExecReturning(ctx, db, &avatar,
+		`UPDATE users SET avatar = $1 WHERE id = $2 RETURNING avatar`, []byte("png"), 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, []byte("png"), avatar)
+	db.AssertExpectations(t)
+}
+
+// rowsAffectedErrQuerier executes queries with a result that doesn't support RowsAffected, like DDL statements.
+type rowsAffectedErrQuerier struct {
+	*sqlscantest.FakeQuerier
+}
+
+func (rowsAffectedErrQuerier) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return driver.ResultNoRows, nil
+}
+
+func TestExecReturning_rowsAffectedErr_returnsQueryErr(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithQueryInErrors(0, nil))
+	db := newFakeQuerier(t)
+
+	_, err := api.ExecReturning(ctx, rowsAffectedErrQuerier{FakeQuerier: db}, nil, `DELETE FROM users`)
+
+	var queryError *dbscan.QueryError
+	require.ErrorAs(t, err, &queryError)
+	assert.Equal(t, "DELETE FROM users", queryError.Query)
+}
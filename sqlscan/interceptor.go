@@ -0,0 +1,35 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+)
+
+// QueryFunc sends a query to the database through the provided Querier.
+// Rows are scanned after QueryFunc returns, so the context must stay valid until then.
+type QueryFunc func(ctx context.Context, db Querier, query string, args ...interface{}) (*sql.Rows, error)
+
+// Interceptor wraps a QueryFunc to change how queries are sent, e.g. to rewrite the query text,
+// route it to a different Querier or reject it without calling next.
+type Interceptor func(next QueryFunc) QueryFunc
+
+// WithInterceptors adds interceptors that wrap every query sent by the API functions that query rows,
+// such as Select and Get. ExecReturning with a nil destination executes the query without rows,
+// so it isn't intercepted. The first interceptor is the outermost one, it's called first.
+// Calling this option multiple times appends to the list of interceptors.
+func WithInterceptors(interceptors ...Interceptor) APIOption {
+	return func(api *API) {
+		api.interceptors = append(api.interceptors, interceptors...)
+	}
+}
+
+func queryContext(ctx context.Context, db Querier, query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(ctx, query, args...)
+}
+
+func buildQueryFunc(queryFn QueryFunc, interceptors []Interceptor) QueryFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		queryFn = interceptors[i](queryFn)
+	}
+	return queryFn
+}
@@ -0,0 +1,69 @@
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func appendComment(comment string) sqlscan.Interceptor {
+	return func(next sqlscan.QueryFunc) sqlscan.QueryFunc {
+		return func(ctx context.Context, db sqlscan.Querier, query string, args ...interface{}) (*sql.Rows, error) {
+			return next(ctx, db, query+" /* "+comment+" */", args...)
+		}
+	}
+}
+
+func TestWithInterceptors_order(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t,
+		sqlscan.WithInterceptors(appendComment("first")),
+		sqlscan.WithInterceptors(appendComment("second")),
+	)
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`SELECT id FROM users WHERE id = $1 /* first */ /* second */`).
+		WithArgs(1).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}})
+
+	var id int
+	err := api.Get(ctx, db, &id, `SELECT id FROM users WHERE id = $1`, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, id)
+	db.AssertExpectations(t)
+}
+
+func TestWithInterceptors_routesAndDenies(t *testing.T) {
+	t.Parallel()
+	replica := newFakeQuerier(t)
+	replica.ExpectQuery(`SELECT id FROM users`).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}})
+	errDenied := errors.New("query denied")
+	interceptor := func(next sqlscan.QueryFunc) sqlscan.QueryFunc {
+		return func(ctx context.Context, db sqlscan.Querier, query string, args ...interface{}) (*sql.Rows, error) {
+			if query == `DELETE FROM users RETURNING id` {
+				return nil, errDenied
+			}
+			return next(ctx, replica, query, args...)
+		}
+	}
+	api := newAPI(t, sqlscan.WithInterceptors(interceptor))
+	primary := newFakeQuerier(t)
+
+	var ids []int
+	err := api.Select(ctx, primary, &ids, `SELECT id FROM users`)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	err = api.Select(ctx, primary, &ids, `DELETE FROM users RETURNING id`)
+	assert.ErrorIs(t, err, errDenied)
+
+	replica.AssertExpectations(t)
+	primary.AssertExpectations(t)
+}
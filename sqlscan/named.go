@@ -0,0 +1,191 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// Execer is something that sqlscan can execute queries with.
+// For example, it can be: *sql.DB, *sql.Conn or *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+var (
+	_ Execer = &sql.DB{}
+	_ Execer = &sql.Conn{}
+	_ Execer = &sql.Tx{}
+)
+
+// NamedSelect is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedSelect for details.
+func NamedSelect(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.NamedSelect(ctx, db, dst, query, arg)
+}
+
+// NamedGet is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedGet for details.
+func NamedGet(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	return DefaultAPI.NamedGet(ctx, db, dst, query, arg)
+}
+
+// NamedExec is a package-level helper function that uses the DefaultAPI object.
+// See API.NamedExec for details.
+func NamedExec(ctx context.Context, db Execer, query string, arg interface{}) (sql.Result, error) {
+	return DefaultAPI.NamedExec(ctx, db, query, arg)
+}
+
+// BindNamed is a package-level helper function that uses the DefaultAPI object.
+// See API.BindNamed for details.
+func BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	return DefaultAPI.BindNamed(query, arg)
+}
+
+// NamedSelect is like Select, but it accepts a query with named parameters.
+// See BindNamed for details.
+func (api *API) NamedSelect(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	boundQuery, args, err := api.BindNamed(query, arg)
+	if err != nil {
+		return fmt.Errorf("binding named parameters: %w", err)
+	}
+	return api.Select(ctx, db, dst, boundQuery, args...)
+}
+
+// NamedGet is like Get, but it accepts a query with named parameters.
+// See BindNamed for details.
+func (api *API) NamedGet(ctx context.Context, db Querier, dst interface{}, query string, arg interface{}) error {
+	boundQuery, args, err := api.BindNamed(query, arg)
+	if err != nil {
+		return fmt.Errorf("binding named parameters: %w", err)
+	}
+	return api.Get(ctx, db, dst, boundQuery, args...)
+}
+
+// NamedExec executes a query with named parameters without returning any rows.
+// See BindNamed for details.
+func (api *API) NamedExec(ctx context.Context, db Execer, query string, arg interface{}) (sql.Result, error) {
+	boundQuery, args, err := api.BindNamed(query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("binding named parameters: %w", err)
+	}
+	res, err := db.ExecContext(ctx, boundQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("scany: exec query: %w", err)
+	}
+	return res, nil
+}
+
+// BindNamed rewrites a query with named parameters like ":name" to the positional placeholders
+// configured via WithPlaceholder and returns the rewritten query together with the arguments.
+// The argument must be a struct, a pointer to a struct or a map[string]interface{}.
+// Struct fields are matched to parameter names by the same rules as struct fields are matched to columns
+// when scanning, so nested struct fields are referenced as ":nested.field".
+//
+// Colons inside string literals, quoted identifiers and comments are left as is,
+// so are Postgres type casts, e.g. "::text".
+func (api *API) BindNamed(query string, arg interface{}) (string, []interface{}, error) {
+	argsMap, ok := arg.(map[string]interface{})
+	if !ok {
+		var err error
+		argsMap, err = api.dbscanAPI.ValuesMap(arg)
+		if err != nil {
+			return "", nil, fmt.Errorf("getting struct values: %w", err)
+		}
+	}
+	return bindNamed(query, api.dbscanAPI.ColumnSeparator(), api.placeholderFn, argsMap)
+}
+
+func bindNamed(
+	query, separator string, placeholderFn dbscan.PlaceholderFunc, argsMap map[string]interface{},
+) (string, []interface{}, error) {
+	var sb strings.Builder
+	sb.Grow(len(query))
+	var args []interface{}
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end := skipQuoted(query, i, c)
+			sb.WriteString(query[i:end])
+			i = end
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			sb.WriteString(query[i : i+end])
+			i += end
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			sb.WriteString(query[i : i+end])
+			i += end
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			sb.WriteString("::")
+			i += 2
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := scanName(query, i+1, separator)
+			name := query[i+1 : end]
+			value, ok := argsMap[name]
+			if !ok {
+				return "", nil, fmt.Errorf("scany: named parameter '%s': no corresponding field or key found", name)
+			}
+			args = append(args, value)
+			sb.WriteString(placeholderFn(len(args)))
+			i = end
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String(), args, nil
+}
+
+// skipQuoted returns the position right after the quoted section that starts at i.
+// Doubled quote characters are treated as escaped quotes.
+func skipQuoted(query string, i int, quote byte) int {
+	for j := i + 1; j < len(query); j++ {
+		if query[j] != quote {
+			continue
+		}
+		if j+1 < len(query) && query[j+1] == quote {
+			j++
+			continue
+		}
+		return j + 1
+	}
+	return len(query)
+}
+
+// scanName returns the position right after the parameter name that starts at i.
+// A name may contain the column separator to reference nested struct fields.
+func scanName(query string, i int, separator string) int {
+	for i < len(query) {
+		if isNameChar(query[i]) {
+			i++
+			continue
+		}
+		next := i + len(separator)
+		if separator != "" && strings.HasPrefix(query[i:], separator) && next < len(query) && isNameStart(query[next]) {
+			i = next
+			continue
+		}
+		break
+	}
+	return i
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameChar(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,132 @@
+package sqlscan_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestBindNamed(t *testing.T) {
+	t.Parallel()
+	type Nested struct {
+		Baz string
+	}
+	type Arg struct {
+		Foo    string
+		Bar    string `db:"bar_column"`
+		Nested Nested
+	}
+	arg := &Arg{Foo: "foo val", Bar: "bar val", Nested: Nested{Baz: "baz val"}}
+	cases := []struct {
+		name          string
+		query         string
+		arg           interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "struct",
+			query:         `SELECT * FROM t WHERE foo = :foo AND bar = :bar_column`,
+			arg:           arg,
+			expectedQuery: `SELECT * FROM t WHERE foo = $1 AND bar = $2`,
+			expectedArgs:  []interface{}{"foo val", "bar val"},
+		},
+		{
+			name:          "nested struct",
+			query:         `SELECT * FROM t WHERE baz = :nested.baz`,
+			arg:           arg,
+			expectedQuery: `SELECT * FROM t WHERE baz = $1`,
+			expectedArgs:  []interface{}{"baz val"},
+		},
+		{
+			name:          "map",
+			query:         `SELECT * FROM t WHERE foo = :foo OR foo = :foo`,
+			arg:           map[string]interface{}{"foo": "foo val"},
+			expectedQuery: `SELECT * FROM t WHERE foo = $1 OR foo = $2`,
+			expectedArgs:  []interface{}{"foo val", "foo val"},
+		},
+		{
+			name: "literals comments and casts",
+			query: `SELECT ':foo', 'it'':s :foo', ":foo" -- :foo
+				/* :foo */ FROM t WHERE foo = :foo::text`,
+			arg: arg,
+			expectedQuery: `SELECT ':foo', 'it'':s :foo', ":foo" -- :foo
+				/* :foo */ FROM t WHERE foo = $1::text`,
+			expectedArgs: []interface{}{"foo val"},
+		},
+		{
+			name:          "trailing separator",
+			query:         `SELECT :foo.`,
+			arg:           arg,
+			expectedQuery: `SELECT $1.`,
+			expectedArgs:  []interface{}{"foo val"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			query, args, err := testAPI.BindNamed(tc.query, tc.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQuery, query)
+			assert.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
+
+func TestBindNamed_unknownName_returnsErr(t *testing.T) {
+	t.Parallel()
+	_, _, err := testAPI.BindNamed(`SELECT :baz`, &testModel{})
+
+	assert.EqualError(t, err, "scany: named parameter 'baz': no corresponding field or key found")
+}
+
+func TestNamedSelect(t *testing.T) {
+	t.Parallel()
+	expected := []*testModel{
+		{Foo: "foo val 2", Bar: "bar val 2"},
+	}
+
+	var got []*testModel
+	err := testAPI.NamedSelect(ctx, testDB, &got, `
+		SELECT *
+		FROM (
+			VALUES ('foo val', 'bar val'), ('foo val 2', 'bar val 2'), ('foo val 3', 'bar val 3')
+		) AS t (foo, bar)
+		WHERE foo = :foo
+	`, &testModel{Foo: "foo val 2"})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestNamedGet(t *testing.T) {
+	t.Parallel()
+	expected := testModel{Foo: "foo val", Bar: "bar val"}
+
+	var got testModel
+	err := testAPI.NamedGet(ctx, testDB, &got, `SELECT :foo::text AS foo, :bar::text AS bar`, map[string]interface{}{
+		"foo": "foo val",
+		"bar": "bar val",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+}
+
+func TestNamedExec(t *testing.T) {
+	t.Parallel()
+	_, err := testAPI.NamedExec(ctx, testDB, `SELECT :foo::text`, &testModel{Foo: "foo val"})
+	require.NoError(t, err)
+}
+
+func TestNamedGet_bindError_returnsErr(t *testing.T) {
+	t.Parallel()
+	var got testModel
+	err := sqlscan.NamedGet(ctx, testDB, &got, `SELECT :baz AS foo`, &testModel{})
+
+	assert.EqualError(t, err, "binding named parameters: scany: named parameter 'baz': no corresponding field or key found")
+}
@@ -0,0 +1,36 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+)
+
+// WithRetryPolicy makes Select and Get, and the functions built on top of them, retry queries
+// that fail with a transient error before any row is read, e.g. a serialization failure.
+// Queries made on a *sql.Tx or StmtTx aren't retried: after an error the transaction is aborted,
+// so the error is returned as is for the whole transaction to be retried, e.g. by WithRetryableTx.
+// See dbscan.RetryPolicy for details.
+func WithRetryPolicy(policy dbscan.RetryPolicy) APIOption {
+	return func(api *API) {
+		api.retryPolicy = &policy
+	}
+}
+
+func (api *API) retry(ctx context.Context, db Querier, fn func() (rowsRead bool, err error)) error {
+	if api.retryPolicy == nil || isTx(db) {
+		_, err := fn()
+		return err
+	}
+	return api.retryPolicy.Run(ctx, fn)
+}
+
+func isTx(db Querier) bool {
+	switch db.(type) {
+	case *sql.Tx, StmtTx, *StmtTx:
+		return true
+	default:
+		return false
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/internal/notfound"
 )
 
 // Querier is something that sqlscan can query and get the *sql.Rows from.
@@ -51,6 +52,24 @@ func ScanAllSets(dsts []interface{}, rows *sql.Rows) error {
 	return DefaultAPI.ScanAllSets(dsts, rows)
 }
 
+// InsertQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.InsertQuery for details.
+func InsertQuery(table string, src interface{}) (string, []interface{}, error) {
+	return DefaultAPI.InsertQuery(table, src)
+}
+
+// UpdateQuery is a package-level helper function that uses the DefaultAPI object.
+// See API.UpdateQuery for details.
+func UpdateQuery(table string, src interface{}, keyColumns ...string) (string, []interface{}, error) {
+	return DefaultAPI.UpdateQuery(table, src, keyColumns...)
+}
+
+// ScanAllSetsStrict is a package-level helper function that uses the DefaultAPI object.
+// See API.ScanAllSetsStrict for details.
+func ScanAllSetsStrict(dsts []interface{}, rows *sql.Rows) error {
+	return DefaultAPI.ScanAllSetsStrict(dsts, rows)
+}
+
 // RowScanner is a wrapper around the dbscan.RowScanner type.
 // See dbscan.RowScanner for details.
 type RowScanner struct {
@@ -84,44 +103,107 @@ func NewDBScanAPI(opts ...dbscan.APIOption) (*dbscan.API, error) {
 // API is a wrapper around the dbscan.API type.
 // See dbscan.API for details.
 type API struct {
-	dbscanAPI *dbscan.API
+	dbscanAPI     *dbscan.API
+	placeholderFn dbscan.PlaceholderFunc
+	tracer        dbscan.QueryTracer
+	interceptors  []Interceptor
+	queryFn       QueryFunc
+	queryErrors   *queryErrorsConfig
+	retryPolicy   *dbscan.RetryPolicy
+	stmtCache     *stmtCache
 }
 
-// NewAPI creates new API instance from dbscan.API instance.
-func NewAPI(dbscanAPI *dbscan.API) (*API, error) {
-	api := &API{dbscanAPI: dbscanAPI}
+// APIOption is a function type that changes API configuration.
+type APIOption func(api *API)
+
+// NewAPI creates new API instance from dbscan.API instance with provided list of options.
+func NewAPI(dbscanAPI *dbscan.API, opts ...APIOption) (*API, error) {
+	api := &API{
+		dbscanAPI:     dbscanAPI,
+		placeholderFn: dbscan.DollarPlaceholder,
+	}
+	for _, o := range opts {
+		o(api)
+	}
+	baseQueryFn := queryContext
+	if api.stmtCache != nil {
+		baseQueryFn = api.stmtCache.query
+	}
+	api.queryFn = buildQueryFunc(baseQueryFn, api.interceptors)
 	return api, nil
 }
 
+// WithPlaceholder allows to use a custom placeholder format when API builds queries.
+// The default format is dbscan.DollarPlaceholder, e.g. "$1".
+func WithPlaceholder(placeholderFn dbscan.PlaceholderFunc) APIOption {
+	return func(api *API) {
+		api.placeholderFn = placeholderFn
+	}
+}
+
 // Select is a high-level function that queries rows from Querier and calls the ScanAll function.
 // See ScanAll for details.
 func (api *API) Select(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.QueryContext(ctx, query, args...)
+	return api.retry(ctx, db, func() (bool, error) {
+		return api.selectOnce(ctx, db, dst, query, args)
+	})
+}
+
+// selectOnce sends the query once and reports whether any row was read, along with the error.
+func (api *API) selectOnce(ctx context.Context, db Querier, dst interface{}, query string, args []interface{}) (bool, error) {
+	ctx, trace := api.startTrace(ctx, "Select", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
 	if err != nil {
-		return fmt.Errorf("scany: query multiple result rows: %w", err)
+		err = api.queryError(fmt.Errorf("scany: query multiple result rows: %w", err), query, args)
+		trace.end(nil, err)
+		return false, err
 	}
-	if err := api.ScanAll(dst, rows); err != nil {
-		return fmt.Errorf("scanning all: %w", err)
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if err := api.scanAll(dst, cr); err != nil {
+		err = api.queryError(fmt.Errorf("scanning all: %w", err), query, args)
+		trace.end(cr, err)
+		return cr.count > 0, err
 	}
-	return nil
+	trace.end(cr, nil)
+	return cr.count > 0, nil
 }
 
 // Get is a high-level function that queries rows from Querier and calls the ScanOne function.
 // See ScanOne for details.
 func (api *API) Get(ctx context.Context, db Querier, dst interface{}, query string, args ...interface{}) error {
-	rows, err := db.QueryContext(ctx, query, args...)
+	return api.retry(ctx, db, func() (bool, error) {
+		return api.getOnce(ctx, db, dst, query, args)
+	})
+}
+
+// getOnce is like selectOnce, but it scans exactly one row like Get does it.
+func (api *API) getOnce(ctx context.Context, db Querier, dst interface{}, query string, args []interface{}) (bool, error) {
+	ctx, trace := api.startTrace(ctx, "Get", query, args)
+	rows, err := api.queryFn(ctx, db, query, args...)
+	trace.queried()
 	if err != nil {
-		return fmt.Errorf("scany: query one result row: %w", err)
+		err = api.queryError(fmt.Errorf("scany: query one result row: %w", err), query, args)
+		trace.end(nil, err)
+		return false, err
 	}
-	if err := api.ScanOne(dst, rows); err != nil {
-		return fmt.Errorf("scanning one: %w", err)
+	cr := &countingRows{RowsAdapter: NewRowsAdapter(rows)}
+	if err := api.scanOne(dst, cr); err != nil {
+		err = api.queryError(fmt.Errorf("scanning one: %w", err), query, args)
+		trace.end(cr, err)
+		return cr.count > 0, err
 	}
-	return nil
+	trace.end(cr, nil)
+	return cr.count > 0, nil
 }
 
 // ScanAll is a wrapper around the dbscan.ScanAll function.
 // See dbscan.ScanAll for details.
 func (api *API) ScanAll(dst interface{}, rows *sql.Rows) error {
+	return api.scanAll(dst, NewRowsAdapter(rows))
+}
+
+func (api *API) scanAll(dst interface{}, rows dbscan.Rows) error {
 	return api.dbscanAPI.ScanAll(dst, rows)
 }
 
@@ -129,20 +211,46 @@ func (api *API) ScanAll(dst interface{}, rows *sql.Rows) error {
 // See dbscan.ScanOne for details. If no rows are found it
 // returns an sql.ErrNoRows error.
 func (api *API) ScanOne(dst interface{}, rows *sql.Rows) error {
+	return api.scanOne(dst, NewRowsAdapter(rows))
+}
+
+func (api *API) scanOne(dst interface{}, rows dbscan.Rows) error {
 	switch err := api.dbscanAPI.ScanOne(dst, rows); {
 	case dbscan.NotFound(err):
 		return fmt.Errorf("%w", sql.ErrNoRows)
 	case err != nil:
-		return fmt.Errorf("%w", err)
+		return err
 	default:
 		return nil
 	}
 }
 
 // ScanAllSets is a wrapper around the dbscan.ScanAllSets function.
-// See dbscan.ScanAllSets for details.
+// See dbscan.ScanAllSets for details. If a result set scanned with dbscan.One has no rows,
+// it returns an error that wraps sql.ErrNoRows.
 func (api *API) ScanAllSets(dsts []interface{}, rows *sql.Rows) error {
-	return api.dbscanAPI.ScanAllSets(dsts, rows)
+	return notfound.Wrap(api.dbscanAPI.ScanAllSets(dsts, NewRowsAdapter(rows)), sql.ErrNoRows)
+}
+
+// ScanAllSetsStrict is a wrapper around the dbscan.ScanAllSetsStrict function.
+// See dbscan.ScanAllSetsStrict for details. If a result set scanned with dbscan.One has no rows,
+// it returns an error that wraps sql.ErrNoRows.
+func (api *API) ScanAllSetsStrict(dsts []interface{}, rows *sql.Rows) error {
+	return notfound.Wrap(api.dbscanAPI.ScanAllSetsStrict(dsts, NewRowsAdapter(rows)), sql.ErrNoRows)
+}
+
+// InsertQuery is a wrapper around the dbscan.InsertQuery function.
+// It renders placeholders in the format configured via WithPlaceholder.
+// See dbscan.InsertQuery for details.
+func (api *API) InsertQuery(table string, src interface{}) (string, []interface{}, error) {
+	return api.dbscanAPI.InsertQuery(table, src, api.placeholderFn)
+}
+
+// UpdateQuery is a wrapper around the dbscan.UpdateQuery function.
+// It renders placeholders in the format configured via WithPlaceholder.
+// See dbscan.UpdateQuery for details.
+func (api *API) UpdateQuery(table string, src interface{}, keyColumns ...string) (string, []interface{}, error) {
+	return api.dbscanAPI.UpdateQuery(table, src, api.placeholderFn, keyColumns...)
 }
 
 // NotFound is a helper function to check if an error
@@ -153,13 +261,13 @@ func NotFound(err error) bool {
 
 // NewRowScanner returns a new RowScanner instance.
 func (api *API) NewRowScanner(rows *sql.Rows) *RowScanner {
-	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(rows)}
+	return &RowScanner{RowScanner: api.dbscanAPI.NewRowScanner(NewRowsAdapter(rows))}
 }
 
 // ScanRow is a wrapper around the dbscan.ScanRow function.
 // See dbscan.ScanRow for details.
 func (api *API) ScanRow(dst interface{}, rows *sql.Rows) error {
-	return api.dbscanAPI.ScanRow(dst, rows)
+	return api.dbscanAPI.ScanRow(dst, NewRowsAdapter(rows))
 }
 
 func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
@@ -170,8 +278,8 @@ func mustNewDBScanAPI(opts ...dbscan.APIOption) *dbscan.API {
 	return api
 }
 
-func mustNewAPI(dbscanAPI *dbscan.API) *API {
-	api, err := NewAPI(dbscanAPI)
+func mustNewAPI(dbscanAPI *dbscan.API, opts ...APIOption) *API {
+	api, err := NewAPI(dbscanAPI, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -9,12 +9,13 @@ import (
 	"os"
 	"testing"
 
-	"github.com/cockroachdb/cockroach-go/v2/testserver"
-	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/internal/testdb"
 	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
 )
 
 var (
@@ -277,6 +278,47 @@ func requireNoRowsErrorsAndClose(t *testing.T, rows *sql.Rows) {
 	require.NoError(t, rows.Close())
 }
 
+func TestInsertQuery(t *testing.T) {
+	t.Parallel()
+	src := &testModel{Foo: "foo val", Bar: "bar val"}
+
+	query, args, err := testAPI.InsertQuery("test_table", src)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO test_table (foo, bar) VALUES ($1, $2)", query)
+	assert.Equal(t, []interface{}{"foo val", "bar val"}, args)
+}
+
+func TestInsertQuery_withPlaceholder(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithPlaceholder(dbscan.QuestionPlaceholder))
+	require.NoError(t, err)
+	src := &testModel{Foo: "foo val", Bar: "bar val"}
+
+	query, args, err := api.InsertQuery("test_table", src)
+	require.NoError(t, err)
+
+	assert.Equal(t, "INSERT INTO test_table (foo, bar) VALUES (?, ?)", query)
+	assert.Equal(t, []interface{}{"foo val", "bar val"}, args)
+}
+
+func TestUpdateQuery_withPlaceholder(t *testing.T) {
+	t.Parallel()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, sqlscan.WithPlaceholder(dbscan.QuestionPlaceholder))
+	require.NoError(t, err)
+	src := &testModel{Foo: "foo val", Bar: "bar val"}
+
+	query, args, err := api.UpdateQuery("test_table", src, "foo")
+	require.NoError(t, err)
+
+	assert.Equal(t, "UPDATE test_table SET bar = ? WHERE foo = ?", query)
+	assert.Equal(t, []interface{}{"bar val", "foo val"}, args)
+}
+
 func getAPI() (*sqlscan.API, error) {
 	dbscanAPI, err := sqlscan.NewDBScanAPI()
 	if err != nil {
@@ -289,15 +331,31 @@ func getAPI() (*sqlscan.API, error) {
 	return api, nil
 }
 
+// newAPI returns an API with the given options for tests that run against sqlscantest.FakeQuerier.
+func newAPI(t *testing.T, opts ...sqlscan.APIOption) *sqlscan.API {
+	t.Helper()
+	dbscanAPI, err := sqlscan.NewDBScanAPI()
+	require.NoError(t, err)
+	api, err := sqlscan.NewAPI(dbscanAPI, opts...)
+	require.NoError(t, err)
+	return api
+}
+
+func newFakeQuerier(t *testing.T) *sqlscantest.FakeQuerier {
+	t.Helper()
+	fq := sqlscantest.NewFakeQuerier()
+	t.Cleanup(func() { require.NoError(t, fq.Close()) })
+	return fq
+}
+
 func TestMain(m *testing.M) {
 	exitCode := func() int {
 		flag.Parse()
-		ts, err := testserver.NewTestServer()
-		if err != nil {
-			panic(err)
-		}
+		// The server is started by the first test that connects to testDB.
+		var ts testdb.Server
 		defer ts.Stop()
-		testDB, err = sql.Open("pgx", ts.PGURL().String())
+		var err error
+		testDB, err = ts.DB()
 		if err != nil {
 			panic(err)
 		}
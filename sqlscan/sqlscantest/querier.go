@@ -0,0 +1,261 @@
+package sqlscantest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// DriverName is the name the fake driver is registered with in database/sql.
+const DriverName = "sqlscantest"
+
+var (
+	queriers   sync.Map
+	querierSeq int64
+)
+
+func init() {
+	sql.Register(DriverName, fakeDriver{})
+}
+
+// FakeQuerier implements sqlscan.Querier and sqlscan.Execer
+// and answers queries according to the expectations.
+// Queries that don't match any expectation fail with an error that describes the difference.
+// Call Close when the test is done, e.g. with t.Cleanup, to release the fake database.
+type FakeQuerier struct {
+	dbscantest.Expectations
+	db  *sql.DB
+	dsn string
+
+	mu       sync.Mutex
+	prepared []string
+}
+
+var (
+	_ sqlscan.Querier = &FakeQuerier{}
+	_ sqlscan.Execer  = &FakeQuerier{}
+)
+
+// NewFakeQuerier returns a new FakeQuerier without any expectations.
+func NewFakeQuerier() *FakeQuerier {
+	fq := &FakeQuerier{dsn: strconv.FormatInt(atomic.AddInt64(&querierSeq, 1), 10)}
+	queriers.Store(fq.dsn, fq)
+	// sql.Open never fails for a registered driver.
+	fq.db, _ = sql.Open(DriverName, fq.dsn)
+	return fq
+}
+
+// Close closes the fake database returned by DB and unregisters the FakeQuerier from the fake driver,
+// so it can be garbage collected. The FakeQuerier can't be used after that.
+func (fq *FakeQuerier) Close() error {
+	queriers.Delete(fq.dsn)
+	return fq.db.Close()
+}
+
+// DB returns *sql.DB backed by the fake driver, that answers queries the same way as FakeQuerier does.
+// It allows testing code that requires *sql.DB rather than sqlscan.Querier.
+func (fq *FakeQuerier) DB() *sql.DB {
+	return fq.db
+}
+
+// PreparedQueries returns the queries prepared on the fake database, in the order they were prepared.
+// Prepared statements are matched against expectations each time they are executed.
+func (fq *FakeQuerier) PreparedQueries() []string {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+	return append([]string(nil), fq.prepared...)
+}
+
+// QueryContext implements the sqlscan.Querier.QueryContext method.
+func (fq *FakeQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return fq.db.QueryContext(ctx, query, args...)
+}
+
+// ExecContext implements the sqlscan.Execer.ExecContext method.
+func (fq *FakeQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return fq.db.ExecContext(ctx, query, args...)
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	fq, ok := queriers.Load(dsn)
+	if !ok {
+		return nil, fmt.Errorf("sqlscantest: unknown fake querier: %q", dsn)
+	}
+	return &fakeConn{fq: fq.(*FakeQuerier)}, nil
+}
+
+type fakeConn struct {
+	fq *FakeQuerier
+}
+
+var (
+	_ driver.QueryerContext         = &fakeConn{}
+	_ driver.ExecerContext          = &fakeConn{}
+	_ driver.NamedValueChecker      = &fakeConn{}
+	_ driver.StmtQueryContext       = &fakeStmt{}
+	_ driver.StmtExecContext        = &fakeStmt{}
+	_ driver.RowsNextResultSet      = &fakeRows{}
+	_ driver.RowsColumnTypeScanType = &fakeRows{}
+)
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	c.fq.mu.Lock()
+	c.fq.prepared = append(c.fq.prepared, query)
+	c.fq.mu.Unlock()
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+// CheckNamedValue passes all arguments to the driver as is, so they are matched against expectations unchanged.
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if len(args) == 1 {
+		if sets, ok := args[0].Value.(replayedSets); ok {
+			return &fakeRows{sets: sets}, nil
+		}
+	}
+	ex, err := c.fq.Match(query, namedValuesToArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	if err := ex.Err(); err != nil {
+		return nil, err
+	}
+	rows := &fakeRows{sets: ex.ResultSets()}
+	rows.errAfter, rows.err = ex.RowsErr()
+	return rows, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ex, err := c.fq.Match(query, namedValuesToArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	if err := ex.Err(); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(ex.RowsAffected()), nil
+}
+
+// replayedSets is passed as the only argument of a query to get rows over the result sets
+// without matching the query against the expectations, see FakeQuerier.rows.
+type replayedSets []dbscantest.ResultSet
+
+// rows returns *sql.Rows over the given result sets.
+func (fq *FakeQuerier) rows(ctx context.Context, query string, sets []dbscantest.ResultSet) (*sql.Rows, error) {
+	return fq.db.QueryContext(ctx, query, replayedSets(sets))
+}
+
+func namedValuesToArgs(values []driver.NamedValue) []interface{} {
+	args := make([]interface{}, len(values))
+	for i, v := range values {
+		args[i] = v.Value
+	}
+	return args
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+// NumInput returns -1, so database/sql doesn't check the number of arguments.
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+
+func (s *fakeStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func (s *fakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct {
+	sets []dbscantest.ResultSet
+	set  int
+	row  int
+	// err is returned by Next after errAfter rows of the first result set.
+	err      error
+	errAfter int
+}
+
+func (r *fakeRows) current() dbscantest.ResultSet {
+	if r.set < len(r.sets) {
+		return r.sets[r.set]
+	}
+	return dbscantest.ResultSet{}
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.current().Columns
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.err != nil && r.set == 0 && r.row >= r.errAfter {
+		return r.err
+	}
+	rows := r.current().Rows
+	if r.row >= len(rows) {
+		return io.EOF
+	}
+	for i, v := range rows[r.row] {
+		dest[i] = v
+	}
+	r.row++
+	return nil
+}
+
+func (r *fakeRows) HasNextResultSet() bool {
+	return r.set+1 < len(r.sets)
+}
+
+func (r *fakeRows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = 0
+	return nil
+}
+
+var interfaceType = reflect.TypeOf((*interface{})(nil)).Elem()
+
+// ColumnTypeScanType returns the type from ResultSet.ColumnTypes if set.
+func (r *fakeRows) ColumnTypeScanType(index int) reflect.Type {
+	if types := r.current().ColumnTypes; types != nil && types[index] != nil {
+		return types[index]
+	}
+	return interfaceType
+}
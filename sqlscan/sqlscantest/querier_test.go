@@ -0,0 +1,131 @@
+package sqlscantest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+var ctx = context.Background()
+
+func newFakeQuerier(t *testing.T) *sqlscantest.FakeQuerier {
+	t.Helper()
+	fq := sqlscantest.NewFakeQuerier()
+	t.Cleanup(func() { require.NoError(t, fq.Close()) })
+	return fq
+}
+
+type user struct {
+	ID   int
+	Name string
+	Bio  *string
+}
+
+func TestFakeQuerier_Select(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`SELECT id, name, bio FROM users WHERE name = $1`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id", "name", "bio"}, [][]interface{}{
+			{int64(1), "Bob", nil},
+			{int64(2), []byte("Bob"), "Likes Go"},
+		})
+	bio := "Likes Go"
+	expected := []*user{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Bob", Bio: &bio}}
+
+	var got []*user
+	err := sqlscan.Select(ctx, db, &got, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, got)
+	db.AssertExpectations(t)
+}
+
+func TestFakeQuerier_ExpectQueryRegexp(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQueryRegexp(`^SELECT count\(\*\) FROM users`).
+		WithArgs(dbscantest.AnyArg()).
+		WillReturnRows([]string{"count"}, [][]interface{}{{int64(2)}}).
+		Times(2)
+
+	for i := 0; i < 2; i++ {
+		var count int
+		err := sqlscan.Get(ctx, db, &count, `SELECT count(*) FROM users WHERE age > $1`, i)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	}
+	db.AssertExpectations(t)
+}
+
+func TestFakeQuerier_ExecContext(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`DELETE FROM users`).WillReturnRowsAffected(3)
+
+	res, err := db.ExecContext(ctx, `DELETE FROM users`)
+	require.NoError(t, err)
+	rowsAffected, err := res.RowsAffected()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), rowsAffected)
+}
+
+func TestFakeQuerier_queryError_propagatesErr(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	queryErr := errors.New("connection refused")
+	db.ExpectQuery(`SELECT id FROM users`).WillReturnError(queryErr)
+
+	var ids []int
+	err := sqlscan.Select(ctx, db, &ids, `SELECT id FROM users`)
+
+	assert.ErrorIs(t, err, queryErr)
+}
+
+func TestFakeQuerier_unexpectedQuery_returnsErr(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`SELECT id FROM users WHERE name = $1`).WithArgs("Bob")
+
+	var ids []int
+	err := sqlscan.Select(ctx, db, &ids, `SELECT id FROM users WHERE name = $1`, "Alice")
+
+	expectedErr := "scany: query multiple result rows: dbscantest: unexpected query:\n" +
+		"query: SELECT id FROM users WHERE name = $1\n" +
+		"args:\n" +
+		"  0: \"Alice\"\n" +
+		"diff with the expected query:\n" +
+		"--- Expected\n" +
+		"+++ Actual\n" +
+		" query: SELECT id FROM users WHERE name = $1\n" +
+		" args:\n" +
+		"-  0: \"Bob\"\n" +
+		"+  0: \"Alice\""
+	assert.EqualError(t, err, expectedErr)
+	assert.EqualError(t, db.ExpectationsWereMet(), "dbscantest: expected queries weren't made:\n"+
+		"query: SELECT id FROM users WHERE name = $1\n"+
+		"args:\n"+
+		"  0: \"Bob\"\n"+
+		"called 0 out of 1 times")
+}
+
+func TestFakeQuerier_Close(t *testing.T) {
+	t.Parallel()
+	db := sqlscantest.NewFakeQuerier()
+	db.ExpectQuery(`SELECT 1`).WillReturnRows([]string{"one"}, [][]interface{}{{int64(1)}})
+
+	err := db.Close()
+	require.NoError(t, err)
+
+	var got int
+	err = sqlscan.Get(ctx, db, &got, `SELECT 1`)
+	assert.EqualError(t, err, "scany: query one result row: sql: database is closed")
+}
@@ -0,0 +1,117 @@
+package sqlscantest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/dbscan/dbscantest"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+// RecordingQuerier wraps a real sqlscan.Querier and records all queries with their results,
+// so they can be saved as a golden file with Save and replayed later with NewReplayQuerier.
+// Results are read from the real rows entirely and served from memory, each call gets its own results,
+// so RecordingQuerier is safe for concurrent use. Call Close when the test is done.
+type RecordingQuerier struct {
+	*dbscantest.Recorder
+	db   sqlscan.Querier
+	fake *FakeQuerier
+}
+
+var (
+	_ sqlscan.Querier = &RecordingQuerier{}
+	_ sqlscan.Execer  = &RecordingQuerier{}
+)
+
+// NewRecordingQuerier returns a new RecordingQuerier that sends queries to db.
+func NewRecordingQuerier(db sqlscan.Querier) *RecordingQuerier {
+	return &RecordingQuerier{Recorder: dbscantest.NewRecorder(), db: db, fake: NewFakeQuerier()}
+}
+
+// Close releases the fake database that serves the recorded results, see FakeQuerier.Close.
+// It doesn't close the wrapped querier.
+func (rq *RecordingQuerier) Close() error {
+	return rq.fake.Close()
+}
+
+// QueryContext implements the sqlscan.Querier.QueryContext method.
+func (rq *RecordingQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := rq.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if recordErr := rq.RecordQuery(query, args, nil, err); recordErr != nil {
+			return nil, fmt.Errorf("sqlscantest: recording: %w", recordErr)
+		}
+		return nil, err
+	}
+	sets, err := readResultSets(rows)
+	if recordErr := rq.RecordQuery(query, args, sets, err); recordErr != nil {
+		return nil, fmt.Errorf("sqlscantest: recording: %w", recordErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rq.fake.rows(ctx, query, sets)
+}
+
+// ExecContext implements the sqlscan.Execer.ExecContext method.
+// It returns an error if the wrapped querier doesn't implement sqlscan.Execer.
+func (rq *RecordingQuerier) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	execer, ok := rq.db.(sqlscan.Execer)
+	if !ok {
+		return nil, fmt.Errorf("sqlscantest: %T doesn't implement sqlscan.Execer", rq.db)
+	}
+	res, err := execer.ExecContext(ctx, query, args...)
+	var rowsAffected int64
+	if err == nil {
+		rowsAffected, err = res.RowsAffected()
+	}
+	if recordErr := rq.RecordExec(query, args, rowsAffected, err); recordErr != nil {
+		return nil, fmt.Errorf("sqlscantest: recording: %w", recordErr)
+	}
+	return res, err
+}
+
+func readResultSets(rows *sql.Rows) ([]dbscantest.ResultSet, error) {
+	defer rows.Close() //nolint: errcheck
+	var sets []dbscantest.ResultSet
+	for {
+		columns, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		set := dbscantest.ResultSet{Columns: columns}
+		for rows.Next() {
+			values := make([]interface{}, len(columns))
+			scans := make([]interface{}, len(columns))
+			for i := range values {
+				scans[i] = &values[i]
+			}
+			if err := rows.Scan(scans...); err != nil {
+				return nil, err
+			}
+			set.Rows = append(set.Rows, values)
+		}
+		sets = append(sets, set)
+		if !rows.NextResultSet() {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sets, rows.Close()
+}
+
+// NewReplayQuerier returns a FakeQuerier that expects the queries recorded in the golden file
+// and serves the recorded results for them.
+// A query with a different text or arguments fails with an error that describes the difference.
+func NewReplayQuerier(path string) (*FakeQuerier, error) {
+	recording, err := dbscantest.LoadRecording(path)
+	if err != nil {
+		return nil, err
+	}
+	fq := NewFakeQuerier()
+	fq.ExpectRecording(recording)
+	return fq, nil
+}
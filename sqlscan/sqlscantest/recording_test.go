@@ -0,0 +1,103 @@
+package sqlscantest_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+	"github.com/georgysavva/scany/v2/sqlscan/sqlscantest"
+)
+
+func TestRecordingQuerier_replay(t *testing.T) {
+	t.Parallel()
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`SELECT id, name, bio FROM users WHERE name = $1`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id", "name", "bio"}, [][]interface{}{
+			{int64(1), "Bob", nil},
+			{int64(2), "Bob", "Likes Go"},
+		})
+	bio := "Likes Go"
+	expected := []*user{{ID: 1, Name: "Bob"}, {ID: 2, Name: "Bob", Bio: &bio}}
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	recorder := sqlscantest.NewRecordingQuerier(db)
+	t.Cleanup(func() { require.NoError(t, recorder.Close()) })
+	var recorded []*user
+	err := sqlscan.Select(ctx, recorder, &recorded, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save(path))
+	assert.Equal(t, expected, recorded)
+
+	replay, err := sqlscantest.NewReplayQuerier(path)
+	require.NoError(t, err)
+	defer replay.Close() //nolint: errcheck
+	var replayed []*user
+	err = sqlscan.Select(ctx, replay, &replayed, `SELECT id, name, bio FROM users WHERE name = $1`, "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, expected, replayed)
+	replay.AssertExpectations(t)
+
+	replay, err = sqlscantest.NewReplayQuerier(path)
+	require.NoError(t, err)
+	defer replay.Close() //nolint: errcheck
+	err = sqlscan.Select(ctx, replay, &replayed, `SELECT id, name, bio FROM users WHERE name = $1`, "Alice")
+	assert.ErrorContains(t, err, "unexpected query")
+}
+
+// barrierQuerier holds queries until the expected number of them is waiting.
+type barrierQuerier struct {
+	*sqlscantest.FakeQuerier
+	mu      sync.Mutex
+	waiting int
+	release chan struct{}
+}
+
+func (bq *barrierQuerier) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := bq.FakeQuerier.QueryContext(ctx, query, args...)
+	bq.mu.Lock()
+	bq.waiting--
+	if bq.waiting == 0 {
+		close(bq.release)
+	}
+	bq.mu.Unlock()
+	<-bq.release
+	return rows, err
+}
+
+func TestRecordingQuerier_concurrentQueries_getOwnResults(t *testing.T) {
+	t.Parallel()
+	const queries = 20
+	db := newFakeQuerier(t)
+	for i := 0; i < queries; i++ {
+		db.ExpectQuery(`SELECT name FROM users WHERE id = $1`).
+			WithArgs(i).
+			WillReturnRows([]string{"name"}, [][]interface{}{{fmt.Sprintf("user %d", i)}})
+	}
+	// All queries reach the database before any of them is replayed, so their replays interleave.
+	barrier := &barrierQuerier{FakeQuerier: db, waiting: queries, release: make(chan struct{})}
+	recorder := sqlscantest.NewRecordingQuerier(barrier)
+	t.Cleanup(func() { require.NoError(t, recorder.Close()) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < queries; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var name string
+			err := sqlscan.Get(ctx, recorder, &name, `SELECT name FROM users WHERE id = $1`, i)
+			assert.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("user %d", i), name)
+		}()
+	}
+	wg.Wait()
+	db.AssertExpectations(t)
+}
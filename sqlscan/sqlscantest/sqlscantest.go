@@ -0,0 +1,35 @@
+// Package sqlscantest provides a fake sqlscan.Querier for unit tests.
+/*
+FakeQuerier returns canned rows for expected queries, so code that uses sqlscan can be tested without a database:
+
+	db := sqlscantest.NewFakeQuerier()
+	t.Cleanup(func() { db.Close() })
+	db.ExpectQuery(`SELECT id, name FROM users WHERE id = $1`).
+		WithArgs(1).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{1, "Bob"}})
+
+	var user User
+	err := sqlscan.Get(ctx, db, &user, `SELECT id, name FROM users WHERE id = $1`, 1)
+	db.AssertExpectations(t)
+
+Rows are returned as real *sql.Rows produced by a fake database/sql driver,
+so values are converted into destinations by database/sql itself.
+Every FakeQuerier is registered in the fake driver until it's closed, don't forget to call Close.
+See dbscantest.Expectations for details about matching queries.
+
+Golden tests
+
+RecordingQuerier wraps a real database connection and records queries along with their results,
+the recording is then saved to a golden file and replayed without a database by NewReplayQuerier:
+
+	if *update {
+		recorder := sqlscantest.NewRecordingQuerier(realDB)
+		runQueries(ctx, recorder)
+		err := recorder.Save("testdata/users.json")
+	}
+	db, err := sqlscantest.NewReplayQuerier("testdata/users.json")
+	runQueries(ctx, db)
+
+Replaying a query with a different text or arguments fails with an error that shows the difference.
+*/
+package sqlscantest
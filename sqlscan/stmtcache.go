@@ -0,0 +1,268 @@
+package sqlscan
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// WithStatementCache makes the API functions that send queries, such as Select, Get and ExecReturning,
+// use prepared statements and keep up to size of them in a least recently used cache.
+// Statements are cached per query text and per *sql.DB or *sql.Conn they are prepared on.
+// Queries on a StmtTx use statements prepared on its *sql.DB via Tx.StmtContext.
+// If a cached statement fails with an error that indicates a stale plan,
+// e.g. "cached plan must not change result type" after a schema change,
+// it's removed from the cache and the query is prepared again once.
+// In a transaction the error is returned instead, since the transaction is aborted,
+// the next query prepares the statement again.
+// Use API.CloseStatements to close the cached statements, e.g. before closing the database.
+// A size of zero or less disables the cache.
+//
+// Limitation: queries on a bare *sql.Tx aren't prepared, they are sent as is.
+// database/sql doesn't tell which *sql.DB a transaction is started on,
+// and statements prepared on the transaction itself are closed when it's committed or rolled back,
+// so they can't be cached. Pass StmtTx instead of the *sql.Tx to use the cache in a transaction.
+// Queries on queriers of types other than *sql.DB, *sql.Conn and StmtTx aren't prepared either.
+func WithStatementCache(size int) APIOption {
+	return func(api *API) {
+		if size <= 0 {
+			api.stmtCache = nil
+			return
+		}
+		api.stmtCache = newStmtCache(size)
+	}
+}
+
+// StmtPreparer is something that sqlscan can prepare statements on.
+// For example, it can be: *sql.DB or *sql.Conn.
+type StmtPreparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+var (
+	_ StmtPreparer = &sql.DB{}
+	_ StmtPreparer = &sql.Conn{}
+)
+
+// StmtTx is a transaction started on DB. Pass it to the API functions instead of the *sql.Tx
+// for queries in the transaction to use the statements cached for DB, see WithStatementCache:
+//
+//	err := sqlscan.WithTx(ctx, db, nil, func(tx *sql.Tx) error {
+//		return api.Select(ctx, sqlscan.StmtTx{Tx: tx, DB: db}, &users, `SELECT * FROM users`)
+//	})
+type StmtTx struct {
+	*sql.Tx
+	DB *sql.DB
+}
+
+var (
+	_ Querier = StmtTx{}
+	_ Execer  = StmtTx{}
+)
+
+// CloseStatements closes all statements in the cache set with WithStatementCache and empties it.
+// Statements that are in use are closed once the queries sent with them are done.
+// The cache stays usable, queries prepare new statements after that.
+func (api *API) CloseStatements() {
+	if api.stmtCache != nil {
+		api.stmtCache.purge()
+	}
+}
+
+type stmtCacheKey struct {
+	preparer StmtPreparer
+	query    string
+}
+
+type stmtCacheEntry struct {
+	key  stmtCacheKey
+	stmt *sql.Stmt
+	// refs is the number of queries that are being sent with the statement right now,
+	// an evicted statement is closed when it drops to zero.
+	refs    int
+	evicted bool
+}
+
+type stmtCache struct {
+	size    int
+	mu      sync.Mutex
+	entries map[stmtCacheKey]*list.Element
+	// lru holds *stmtCacheEntry values, the most recently used one is at the front.
+	lru *list.List
+}
+
+func newStmtCache(size int) *stmtCache {
+	return &stmtCache{
+		size:    size,
+		entries: make(map[stmtCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// query sends the query with a cached statement, if db allows to prepare it.
+func (sc *stmtCache) query(ctx context.Context, db Querier, query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	prepared, err := sc.run(ctx, db, query, func(stmt *sql.Stmt) (err error) {
+		rows, err = stmt.QueryContext(ctx, args...)
+		return err
+	})
+	if !prepared {
+		return db.QueryContext(ctx, query, args...)
+	}
+	return rows, err
+}
+
+// exec executes the query with a cached statement, if db allows to prepare it, otherwise via execer.
+func (sc *stmtCache) exec(ctx context.Context, db Querier, execer Execer, query string, args ...interface{}) (sql.Result, error) {
+	var res sql.Result
+	prepared, err := sc.run(ctx, db, query, func(stmt *sql.Stmt) (err error) {
+		res, err = stmt.ExecContext(ctx, args...)
+		return err
+	})
+	if !prepared {
+		return execer.ExecContext(ctx, query, args...)
+	}
+	return res, err
+}
+
+// run calls fn with a cached statement for the query and returns its error.
+// It reports false without calling fn if db doesn't allow to prepare statements.
+func (sc *stmtCache) run(ctx context.Context, db Querier, query string, fn func(stmt *sql.Stmt) error) (bool, error) {
+	preparer, tx := stmtPreparerOf(db)
+	if preparer == nil {
+		return false, nil
+	}
+	for attempt := 0; ; attempt++ {
+		entry, err := sc.acquire(ctx, stmtCacheKey{preparer: preparer, query: query})
+		if err != nil {
+			return true, err
+		}
+		stmt := entry.stmt
+		if tx != nil {
+			stmt = tx.StmtContext(ctx, stmt)
+		}
+		err = fn(stmt)
+		// Rows keep the statement open until they are closed, so it's safe to release it right away.
+		sc.release(entry)
+		if err != nil && isStaleStmtErr(err) {
+			sc.invalidate(entry)
+			// Preparing again doesn't help in a transaction, it's aborted after the error.
+			if attempt == 0 && tx == nil {
+				continue
+			}
+		}
+		return true, err
+	}
+}
+
+func (sc *stmtCache) acquire(ctx context.Context, key stmtCacheKey) (*stmtCacheEntry, error) {
+	sc.mu.Lock()
+	if elem, ok := sc.entries[key]; ok {
+		sc.lru.MoveToFront(elem)
+		entry := elem.Value.(*stmtCacheEntry)
+		entry.refs++
+		sc.mu.Unlock()
+		return entry, nil
+	}
+	sc.mu.Unlock()
+
+	// Prepare without holding the lock, concurrent callers might prepare the same query,
+	// only one of the statements ends up in the cache.
+	stmt, err := key.preparer.PrepareContext(ctx, key.query)
+	if err != nil {
+		return nil, err
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if elem, ok := sc.entries[key]; ok {
+		_ = stmt.Close()
+		sc.lru.MoveToFront(elem)
+		entry := elem.Value.(*stmtCacheEntry)
+		entry.refs++
+		return entry, nil
+	}
+	entry := &stmtCacheEntry{key: key, stmt: stmt, refs: 1}
+	sc.entries[key] = sc.lru.PushFront(entry)
+	for sc.lru.Len() > sc.size {
+		sc.removeLocked(sc.lru.Back())
+	}
+	return entry, nil
+}
+
+func (sc *stmtCache) release(entry *stmtCacheEntry) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		_ = entry.stmt.Close()
+	}
+}
+
+func (sc *stmtCache) invalidate(entry *stmtCacheEntry) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if elem, ok := sc.entries[entry.key]; ok && elem.Value == entry {
+		sc.removeLocked(elem)
+	}
+}
+
+func (sc *stmtCache) purge() {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for sc.lru.Len() > 0 {
+		sc.removeLocked(sc.lru.Back())
+	}
+}
+
+func (sc *stmtCache) removeLocked(elem *list.Element) {
+	entry := sc.lru.Remove(elem).(*stmtCacheEntry)
+	delete(sc.entries, entry.key)
+	entry.evicted = true
+	if entry.refs == 0 {
+		_ = entry.stmt.Close()
+	}
+}
+
+// stmtPreparerOf returns what statements for db should be prepared on,
+// and the transaction to bind them to, if db is a transaction.
+func stmtPreparerOf(db Querier) (StmtPreparer, *sql.Tx) {
+	switch db := db.(type) {
+	case *sql.DB:
+		return db, nil
+	case *sql.Conn:
+		return db, nil
+	case StmtTx:
+		return db.DB, db.Tx
+	case *StmtTx:
+		return db.DB, db.Tx
+	}
+	return nil, nil
+}
+
+// isStaleStmtErr reports whether err means that the prepared statement can't be used anymore:
+// either its plan is invalidated by a schema change, it doesn't exist on the server anymore,
+// or its connection is closed.
+func isStaleStmtErr(err error) bool {
+	if errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var sqlStateErr interface {
+		error
+		SQLState() string
+	}
+	if !errors.As(err, &sqlStateErr) {
+		return false
+	}
+	switch sqlStateErr.SQLState() {
+	case "26000": // invalid_sql_statement_name.
+		return true
+	case "0A000": // feature_not_supported, Postgres uses it for stale plans.
+		return strings.Contains(sqlStateErr.Error(), "cached plan must not change result type")
+	default:
+		return false
+	}
+}
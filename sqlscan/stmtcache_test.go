@@ -0,0 +1,178 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func TestWithStatementCache_reusesStatements(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT name FROM users WHERE id = $1`).
+		WithArgs(1).
+		WillReturnRows([]string{"name"}, [][]interface{}{{"Bob"}}).
+		Times(3)
+
+	for i := 0; i < 3; i++ {
+		var name string
+		err := api.Get(ctx, fq.DB(), &name, `SELECT name FROM users WHERE id = $1`, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", name)
+	}
+
+	assert.Equal(t, []string{`SELECT name FROM users WHERE id = $1`}, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_ExecReturning_nilDst(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`DELETE FROM users WHERE id = $1`).WithArgs(1).WillReturnRowsAffected(1).Times(2)
+
+	for i := 0; i < 2; i++ {
+		n, err := api.ExecReturning(ctx, fq.DB(), nil, `DELETE FROM users WHERE id = $1`, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), n)
+	}
+
+	assert.Equal(t, []string{`DELETE FROM users WHERE id = $1`}, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_evictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(1))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT 1`).WillReturnRows([]string{"n"}, [][]interface{}{{int64(1)}}).Times(2)
+	fq.ExpectQuery(`SELECT 2`).WillReturnRows([]string{"n"}, [][]interface{}{{int64(2)}})
+
+	for _, query := range []string{`SELECT 1`, `SELECT 2`, `SELECT 1`} {
+		var n int
+		err := api.Get(ctx, fq.DB(), &n, query)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{`SELECT 1`, `SELECT 2`, `SELECT 1`}, fq.PreparedQueries())
+}
+
+func TestWithStatementCache_stalePlan_preparesAgain(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT * FROM users`).
+		WillReturnError(&pgconn.PgError{Code: "0A000", Message: "cached plan must not change result type"})
+	fq.ExpectQuery(`SELECT * FROM users`).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bob"}})
+
+	var users []*returnedUser
+	err := api.Select(ctx, fq.DB(), &users, `SELECT * FROM users`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*returnedUser{{ID: 1, Name: "Bob"}}, users)
+	assert.Equal(t, []string{`SELECT * FROM users`, `SELECT * FROM users`}, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_tx(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT name FROM users WHERE id = $1`).
+		WithArgs(1).
+		WillReturnRows([]string{"name"}, [][]interface{}{{"Bob"}}).
+		Times(3)
+
+	err := sqlscan.WithTx(ctx, fq.DB(), nil, func(tx *sql.Tx) error {
+		for i := 0; i < 3; i++ {
+			var name string
+			if err := api.Get(ctx, sqlscan.StmtTx{Tx: tx, DB: fq.DB()}, &name, `SELECT name FROM users WHERE id = $1`, 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The statement is prepared on the *sql.DB, and then on the connection of the transaction.
+	assert.Equal(t, []string{
+		`SELECT name FROM users WHERE id = $1`,
+		`SELECT name FROM users WHERE id = $1`,
+	}, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_bareTx_doesNotPrepare(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT 1`).WillReturnRows([]string{"n"}, [][]interface{}{{int64(1)}})
+
+	err := sqlscan.WithTx(ctx, fq.DB(), nil, func(tx *sql.Tx) error {
+		var n int
+		return api.Get(ctx, tx, &n, `SELECT 1`)
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_stalePlanInTx_returnsErr(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	stalePlan := &pgconn.PgError{Code: "0A000", Message: "cached plan must not change result type"}
+	fq.ExpectQuery(`SELECT * FROM users`).WillReturnError(stalePlan)
+	fq.ExpectQuery(`SELECT * FROM users`).
+		WillReturnRows([]string{"id", "name"}, [][]interface{}{{int64(1), "Bob"}})
+
+	var users []*returnedUser
+	err := sqlscan.WithTx(ctx, fq.DB(), nil, func(tx *sql.Tx) error {
+		return api.Select(ctx, sqlscan.StmtTx{Tx: tx, DB: fq.DB()}, &users, `SELECT * FROM users`)
+	})
+	assert.ErrorIs(t, err, stalePlan)
+
+	// The stale statement is evicted, so the next query prepares it again.
+	err = api.Select(ctx, fq.DB(), &users, `SELECT * FROM users`)
+	require.NoError(t, err)
+	assert.Equal(t, []*returnedUser{{ID: 1, Name: "Bob"}}, users)
+	fq.AssertExpectations(t)
+}
+
+func TestAPI_CloseStatements(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT 1`).WillReturnRows([]string{"n"}, [][]interface{}{{int64(1)}}).Times(2)
+
+	var n int
+	err := api.Get(ctx, fq.DB(), &n, `SELECT 1`)
+	require.NoError(t, err)
+	api.CloseStatements()
+	err = api.Get(ctx, fq.DB(), &n, `SELECT 1`)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`SELECT 1`, `SELECT 1`}, fq.PreparedQueries())
+	fq.AssertExpectations(t)
+}
+
+func TestWithStatementCache_otherQuerier_doesNotPrepare(t *testing.T) {
+	t.Parallel()
+	api := newAPI(t, sqlscan.WithStatementCache(2))
+	fq := newFakeQuerier(t)
+	fq.ExpectQuery(`SELECT 1`).WillReturnRows([]string{"n"}, [][]interface{}{{int64(1)}})
+
+	var n int
+	err := api.Get(ctx, fq, &n, `SELECT 1`)
+	require.NoError(t, err)
+
+	assert.Empty(t, fq.PreparedQueries())
+}
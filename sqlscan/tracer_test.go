@@ -0,0 +1,105 @@
+package sqlscan_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/dbscan"
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+type tracerKey struct{}
+
+// recordingHooks collects the traces of finished queries and checks that the context is passed from start to end.
+func recordingHooks(t *testing.T, traces *[]dbscan.QueryEndData) dbscan.QueryHooks {
+	t.Helper()
+	return dbscan.QueryHooks{
+		OnQueryStart: func(ctx context.Context, data dbscan.QueryStartData) context.Context {
+			return context.WithValue(ctx, tracerKey{}, data.Method)
+		},
+		OnQueryEnd: func(ctx context.Context, data dbscan.QueryEndData) {
+			assert.Equal(t, data.Method, ctx.Value(tracerKey{}))
+			*traces = append(*traces, data)
+		},
+	}
+}
+
+func TestWithQueryTracer_Select(t *testing.T) {
+	t.Parallel()
+	var traces []dbscan.QueryEndData
+	api := newAPI(t, sqlscan.WithQueryTracer(recordingHooks(t, &traces)))
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`SELECT id FROM users WHERE id > $1`).
+		WithArgs(0).
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}, {int64(2)}})
+
+	var ids []int
+	err := api.Select(ctx, db, &ids, `SELECT id FROM users WHERE id > $1`, 0)
+	require.NoError(t, err)
+
+	require.Len(t, traces, 1)
+	got := traces[0]
+	assert.Equal(t, dbscan.QueryStartData{
+		Method: "Select",
+		Query:  `SELECT id FROM users WHERE id > $1`,
+		Args:   []interface{}{0},
+	}, got.QueryStartData)
+	assert.Equal(t, 2, got.Rows)
+	assert.NoError(t, got.Err)
+	assert.GreaterOrEqual(t, int64(got.QueryDuration), int64(0))
+	assert.GreaterOrEqual(t, int64(got.ScanDuration), int64(0))
+}
+
+func TestWithQueryTracer_Get_errors(t *testing.T) {
+	t.Parallel()
+	var traces []dbscan.QueryEndData
+	api := newAPI(t, sqlscan.WithQueryTracer(recordingHooks(t, &traces)))
+	db := newFakeQuerier(t)
+	queryErr := errors.New("connection lost")
+	db.ExpectQuery(`SELECT id FROM users WHERE id = 1`).WillReturnError(queryErr)
+	db.ExpectQuery(`SELECT id FROM users WHERE id = 2`).WillReturnRows([]string{"id"}, nil)
+
+	var id int
+	err := api.Get(ctx, db, &id, `SELECT id FROM users WHERE id = 1`)
+	assert.ErrorIs(t, err, queryErr)
+	err = api.Get(ctx, db, &id, `SELECT id FROM users WHERE id = 2`)
+	assert.True(t, sqlscan.NotFound(err))
+
+	require.Len(t, traces, 2)
+	assert.Equal(t, "Get", traces[0].Method)
+	assert.Equal(t, 0, traces[0].Rows)
+	assert.Equal(t, err, traces[1].Err)
+	assert.ErrorIs(t, traces[0].Err, queryErr)
+}
+
+func TestWithQueryTracer_ExecReturning(t *testing.T) {
+	t.Parallel()
+	var traces []dbscan.QueryEndData
+	api := newAPI(t, sqlscan.WithQueryTracer(recordingHooks(t, &traces)))
+	db := newFakeQuerier(t)
+	db.ExpectQuery(`INSERT INTO users (name) VALUES ($1) RETURNING id`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id"}, [][]interface{}{{int64(1)}})
+	db.ExpectQuery(`DELETE FROM users`).WillReturnRowsAffected(2)
+
+	var id int
+	_, err := api.ExecReturning(ctx, db, &id, `INSERT INTO users (name) VALUES ($1) RETURNING id`, "Bob")
+	require.NoError(t, err)
+	_, err = api.ExecReturning(ctx, db, nil, `DELETE FROM users`)
+	require.NoError(t, err)
+
+	require.Len(t, traces, 2)
+	assert.Equal(t, dbscan.QueryStartData{
+		Method: "ExecReturning",
+		Query:  `INSERT INTO users (name) VALUES ($1) RETURNING id`,
+		Args:   []interface{}{"Bob"},
+	}, traces[0].QueryStartData)
+	assert.Equal(t, 1, traces[0].Rows)
+	assert.Equal(t, "ExecReturning", traces[1].Method)
+	assert.Equal(t, `DELETE FROM users`, traces[1].Query)
+	assert.NoError(t, traces[1].Err)
+}
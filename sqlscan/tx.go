@@ -0,0 +1,84 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach-go/v2/crdb"
+
+	"github.com/georgysavva/scany/v2/internal/crdbtx"
+)
+
+// TxBeginner is something that sqlscan can begin a transaction on.
+// For example, it can be: *sql.DB or *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+var (
+	_ TxBeginner = &sql.DB{}
+	_ TxBeginner = &sql.Conn{}
+)
+
+// WithTx begins a transaction with the given options and calls fn with it.
+// If fn returns an error or panics, the transaction is rolled back,
+// otherwise it's committed. The error returned by fn is returned as is, or wrapped if the rollback fails too.
+func WithTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(tx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("scany: rollback transaction: %v: %w", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("scany: commit transaction: %w", err)
+	}
+	return nil
+}
+
+// WithRetryableTx works like WithTx, but it retries fn when the transaction fails
+// with a serialization failure (SQLSTATE 40001), the same way as crdb.ExecuteTx
+// from github.com/cockroachdb/cockroach-go/v2/crdb does it:
+// fn runs after "SAVEPOINT cockroach_restart", the transaction is rolled back to the savepoint
+// and fn is called again on a retryable error, up to 50 retries.
+// fn can be called multiple times, so it must not have side effects outside of the transaction,
+// and it must return the errors of the database library, possibly wrapped, for them to be retried.
+// An error during the final RELEASE SAVEPOINT is returned as *crdb.AmbiguousCommitError.
+// The savepoint protocol is meant for CockroachDB.
+func WithRetryableTx(ctx context.Context, db TxBeginner, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("scany: begin transaction: %w", err)
+	}
+	return crdbtx.ExecuteInTx(ctx, sqlTxAdapter{tx: tx}, func() error { return fn(tx) })
+}
+
+type sqlTxAdapter struct {
+	tx *sql.Tx
+}
+
+var _ crdb.Tx = sqlTxAdapter{}
+
+func (ta sqlTxAdapter) Exec(ctx context.Context, query string, args ...interface{}) error {
+	_, err := ta.tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (ta sqlTxAdapter) Commit(context.Context) error {
+	return ta.tx.Commit()
+}
+
+func (ta sqlTxAdapter) Rollback(context.Context) error {
+	return ta.tx.Rollback()
+}
@@ -0,0 +1,104 @@
+package sqlscan_test
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/georgysavva/scany/v2/sqlscan"
+)
+
+func countRows(t *testing.T, table string) int {
+	t.Helper()
+	var count int
+	err := sqlscan.Get(ctx, testDB, &count, `SELECT count(*) FROM `+table)
+	require.NoError(t, err)
+	return count
+}
+
+func TestWithTx(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.ExecContext(ctx, `CREATE TABLE with_tx (foo TEXT)`)
+	require.NoError(t, err)
+
+	err = sqlscan.WithTx(ctx, testDB, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO with_tx (foo) VALUES ('foo val')`)
+		return err
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, countRows(t, "with_tx"))
+}
+
+func TestWithTx_fnReturnsErr_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.ExecContext(ctx, `CREATE TABLE with_tx_error (foo TEXT)`)
+	require.NoError(t, err)
+	fnErr := errors.New("some error")
+
+	err = sqlscan.WithTx(ctx, testDB, nil, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO with_tx_error (foo) VALUES ('foo val')`)
+		require.NoError(t, err)
+		return fnErr
+	})
+
+	assert.Equal(t, fnErr, err)
+	assert.Equal(t, 0, countRows(t, "with_tx_error"))
+}
+
+func TestWithTx_fnPanics_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.ExecContext(ctx, `CREATE TABLE with_tx_panic (foo TEXT)`)
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "some panic", func() {
+		_ = sqlscan.WithTx(ctx, testDB, nil, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `INSERT INTO with_tx_panic (foo) VALUES ('foo val')`)
+			require.NoError(t, err)
+			panic("some panic")
+		})
+	})
+
+	assert.Equal(t, 0, countRows(t, "with_tx_panic"))
+}
+
+func TestWithRetryableTx_retriesSerializationFailure(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.ExecContext(ctx, `CREATE TABLE with_retryable_tx (foo TEXT)`)
+	require.NoError(t, err)
+	calls := 0
+
+	err = sqlscan.WithRetryableTx(ctx, testDB, nil, func(tx *sql.Tx) error {
+		calls++
+		_, err := tx.ExecContext(ctx, `INSERT INTO with_retryable_tx (foo) VALUES ('foo val')`)
+		require.NoError(t, err)
+		if calls == 1 {
+			return &pgconn.PgError{Code: "40001"}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 1, countRows(t, "with_retryable_tx"))
+}
+
+func TestWithRetryableTx_fnPanics_rollsBack(t *testing.T) {
+	t.Parallel()
+	_, err := testDB.ExecContext(ctx, `CREATE TABLE with_retryable_tx_panic (foo TEXT)`)
+	require.NoError(t, err)
+
+	assert.PanicsWithValue(t, "some panic", func() {
+		_ = sqlscan.WithRetryableTx(ctx, testDB, nil, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, `INSERT INTO with_retryable_tx_panic (foo) VALUES ('foo val')`)
+			require.NoError(t, err)
+			panic("some panic")
+		})
+	})
+
+	assert.Equal(t, 0, countRows(t, "with_retryable_tx_panic"))
+}